@@ -0,0 +1,145 @@
+package path
+
+import (
+	"net/url"
+	"strings"
+)
+
+// PathEscape escapes a value so that it forms a single path segment. As well
+// as the escaping done by url.PathEscape, which includes any '/', the dot
+// segments "." and ".." are escaped so that they are not treated as relative
+// references.
+func PathEscape(s string) string {
+	if s == "." || s == ".." {
+		return strings.Repeat("%2E", len(s))
+	}
+	return url.PathEscape(s)
+}
+
+// PathUnescape decodes a single path segment, converting each "%XX" into the
+// corresponding byte. It is the inverse of PathEscape. It returns an error if
+// any '%' is not followed by two hexadecimal digits.
+func PathUnescape(s string) (string, error) {
+	return url.PathUnescape(s)
+}
+
+// EscapedPath is a path in which each segment is percent-encoded, as in the
+// path of a URL. A slash always separates segments, whereas an escaped slash
+// "%2F" is part of a segment. So "/a%2Fb/c" has two segments, "a/b" and "c".
+type EscapedPath string
+
+// OfEscaped escapes each element using PathEscape and joins them with
+// separating slashes, so each element becomes exactly one segment, even if
+// it contains a slash. Empty elements are ignored. The result is relative;
+// use Append on an absolute EscapedPath to build an absolute one.
+func OfEscaped(elem ...string) EscapedPath {
+	return EscapedPath("").Append(elem...)
+}
+
+// Escaped escapes each segment of the path using PathEscape. The slashes
+// between segments, and any leading or trailing slash, are kept.
+func (path Path) Escaped() EscapedPath {
+	b := &strings.Builder{}
+	for i, seg := range strings.Split(string(path), "/") {
+		if i > 0 {
+			b.WriteByte('/')
+		}
+		b.WriteString(PathEscape(seg))
+	}
+	return EscapedPath(b.String())
+}
+
+// Append escapes each element using PathEscape and joins it to the end of
+// the path, adding separating slashes as necessary, so each element becomes
+// exactly one segment. Empty elements are ignored.
+func (path EscapedPath) Append(elem ...string) EscapedPath {
+	b := &strings.Builder{}
+	b.WriteString(string(path))
+	for _, e := range elem {
+		if e == "" {
+			continue
+		}
+		if b.Len() > 0 && !strings.HasSuffix(b.String(), "/") {
+			b.WriteByte('/')
+		}
+		b.WriteString(PathEscape(e))
+	}
+	return EscapedPath(b.String())
+}
+
+// Segments returns the decoded segments of the path. The segments are split at
+// the unescaped slashes in the same way as Path.Segments, then each is decoded,
+// so an escaped slash remains within its segment. Any segment containing a
+// malformed escape is returned as it is.
+func (path EscapedPath) Segments() []string {
+	segments := Path(path).Segments()
+	for i, seg := range segments {
+		if s, err := PathUnescape(seg); err == nil {
+			segments[i] = s
+		}
+	}
+	return segments
+}
+
+// RawSegments returns the segments of the path without decoding them.
+func (path EscapedPath) RawSegments() []string {
+	return Path(path).Segments()
+}
+
+// Divide divides a path at the nth unescaped slash, not counting the leading
+// slash if there is one. Escaped slashes are not counted.
+//
+// The resulting pair (head, tail) always satisfy
+//
+//	head + tail = path
+func (path EscapedPath) Divide(nth int) (EscapedPath, EscapedPath) {
+	head, tail := Divide(string(path), nth)
+	return EscapedPath(head), EscapedPath(tail)
+}
+
+// Drop is a helper for Divide that returns the tail part only.
+func (path EscapedPath) Drop(unwanted int) EscapedPath {
+	_, tail := path.Divide(unwanted)
+	return tail
+}
+
+// Take is a helper for Divide that returns the head part only.
+func (path EscapedPath) Take(wanted int) EscapedPath {
+	head, _ := path.Divide(wanted)
+	return head
+}
+
+// Next returns the first segment, decoded, and the rest of the path. It can be
+// used for iterating through the path segments; the end has been reached when
+// the tail is empty. A first segment containing a malformed escape is returned
+// as it is.
+func (path EscapedPath) Next() (string, EscapedPath) {
+	head, tail := path.Divide(1)
+	next := strings.TrimPrefix(string(head), "/")
+	if s, err := PathUnescape(next); err == nil {
+		next = s
+	}
+	return next, tail
+}
+
+// Unescape decodes the whole path. Escaped slashes become ordinary slashes, so
+// the segment boundaries may not be preserved; use Segments when they matter.
+func (path EscapedPath) Unescape() (Path, error) {
+	s, err := PathUnescape(string(path))
+	return Path(s), err
+}
+
+// Raw returns the path in its escaped form, as a Path.
+func (path EscapedPath) Raw() Path {
+	return Path(path)
+}
+
+// IsAbs reports whether the path is absolute.
+func (path EscapedPath) IsAbs() bool {
+	return strings.HasPrefix(string(path), "/")
+}
+
+// String simply converts the type to a string.
+func (path EscapedPath) String() string {
+	return string(path)
+}
@@ -0,0 +1,72 @@
+package path
+
+import (
+	"testing"
+)
+
+func TestPathEscape(t *testing.T) {
+	cases := []struct {
+		input, expected string
+	}{
+		{"plain", "plain"},
+		{"a/b", "a%2Fb"},
+		{"a b", "a%20b"},
+		{"50%", "50%25"},
+		{".", "%2E"},
+		{"..", "%2E%2E"},
+		{"...", "..."},
+		{"", ""},
+	}
+
+	for _, c := range cases {
+		actual := PathEscape(c.input)
+		isEqual(t, actual, c.expected, c.input)
+		back, err := PathUnescape(actual)
+		isNil(t, err, c.input)
+		isEqual(t, back, c.input, c.input)
+	}
+
+	_, err := PathUnescape("%zz")
+	isEqual(t, err != nil, true, "")
+}
+
+func TestOfEscaped(t *testing.T) {
+	isEqual(t, OfEscaped("users", "a/b", "", ".."), EscapedPath("users/a%2Fb/%2E%2E"), "")
+	isEqual(t, OfEscaped(), EscapedPath(""), "")
+	isEqual(t, EscapedPath("/api/").Append("x y", "z"), EscapedPath("/api/x%20y/z"), "")
+	isEqual(t, EscapedPath("/api").Append("a/b"), EscapedPath("/api/a%2Fb"), "")
+	isEqual(t, Path("/a b/c?/").Escaped(), EscapedPath("/a%20b/c%3F/"), "")
+}
+
+func TestEscapedPathSegments(t *testing.T) {
+	p := EscapedPath("/files/a%2Fb/c%20d/")
+	isEqual(t, p.Segments(), []string{"files", "a/b", "c d"}, "")
+	isEqual(t, p.RawSegments(), []string{"files", "a%2Fb", "c%20d"}, "")
+	isEqual(t, EscapedPath("/x/%zz").Segments(), []string{"x", "%zz"}, "")
+	isEqual(t, EscapedPath("/").Segments(), []string(nil), "")
+
+	path, err := p.Unescape()
+	isNil(t, err, "")
+	isEqual(t, path, Path("/files/a/b/c d/"), "")
+	isEqual(t, p.Raw(), Path("/files/a%2Fb/c%20d/"), "")
+	isEqual(t, p.IsAbs(), true, "")
+}
+
+func TestEscapedPathDivide(t *testing.T) {
+	p := EscapedPath("/files/a%2Fb/c")
+
+	head, tail := p.Divide(2)
+	isEqual(t, head, EscapedPath("/files/a%2Fb"), "")
+	isEqual(t, tail, EscapedPath("/c"), "")
+	isEqual(t, p.Take(1), EscapedPath("/files"), "")
+	isEqual(t, p.Drop(1), EscapedPath("/a%2Fb/c"), "")
+
+	var segs []string
+	for s, rest := p.Next(); ; s, rest = rest.Next() {
+		segs = append(segs, s)
+		if rest == "" {
+			break
+		}
+	}
+	isEqual(t, segs, []string{"files", "a/b", "c"}, "")
+}
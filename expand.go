@@ -0,0 +1,216 @@
+package path
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxExpansions limits the number of patterns that Expand may produce, so that
+// a short pattern such as "{1..9999999}" cannot exhaust memory.
+const maxExpansions = 10000
+
+var errTooManyExpansions = fmt.Errorf("%w: more than %d brace expansions", ErrBadPattern, maxExpansions)
+
+// Expand performs shell-style brace expansion on a pattern, returning all the
+// concrete patterns that it stands for. Two forms of brace term are supported:
+//
+//	'{' a ',' b ... '}'   alternatives, e.g. "*.{png,jpg}" gives "*.png" and "*.jpg"
+//	'{' m '..' n '}'      numeric range, e.g. "day{1..3}" gives "day1", "day2" and "day3"
+//
+// Alternatives may be empty and may contain further brace terms, which are
+// expanded recursively. Numeric ranges may count down as well as up; if either
+// end has a leading zero, all the numbers are zero-padded to the same width.
+// A pair of braces containing neither a comma nor a range is left unchanged,
+// as in the shell. A backslash escapes the following character, so "\{" is
+// a literal brace; the backslash itself is retained for later use by Match.
+// Braces and commas within a character class such as "[{,}]" are also literal.
+//
+// At most 10,000 patterns can be produced. The returned error is ErrBadPattern
+// when the braces are unbalanced, or an error that wraps ErrBadPattern when
+// there would be too many patterns.
+func Expand(pattern string) ([]string, error) {
+	if !strings.ContainsAny(pattern, "{}") {
+		return []string{pattern}, nil
+	}
+
+	alts, _, err := expandSequence(pattern, false)
+	if err != nil {
+		return nil, err
+	}
+	return alts, nil
+}
+
+// expandSequence expands text up to the end of the string or, when nested is
+// set, up to an unmatched ',' or '}' which is left at the start of the returned
+// rest.
+func expandSequence(s string, nested bool) (results []string, rest string, err error) {
+	results = []string{""}
+	literal := 0
+
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++ // skip the escaped character
+
+		case '[':
+			// as in Match, braces and commas in a character class are literal
+			if end := classEnd(s[i+1:]); end >= 0 {
+				i += end + 1
+			}
+
+		case ',', '}':
+			if !nested {
+				if s[i] == '}' {
+					return nil, "", ErrBadPattern
+				}
+				continue
+			}
+			return product(results, s[literal:i]), s[i:], nil
+
+		case '{':
+			results = product(results, s[literal:i])
+			alts, after, err := expandBraces(s[i+1:])
+			if err != nil {
+				return nil, "", err
+			}
+			results, err = productAll(results, alts)
+			if err != nil {
+				return nil, "", err
+			}
+			i = len(s) - len(after) - 1
+			literal = i + 1
+		}
+	}
+
+	return product(results, s[literal:]), "", nil
+}
+
+// classEnd returns the index of the ']' that ends a character class, which
+// starts just after the opening '['. It returns -1 if there is none.
+func classEnd(s string) int {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++ // skip the escaped character
+		case ']':
+			return i
+		}
+	}
+	return -1
+}
+
+// expandBraces expands the content of a brace term, which starts just after
+// the opening brace. It returns the remainder after the closing brace.
+func expandBraces(s string) (alts []string, rest string, err error) {
+	if close := strings.IndexByte(s, '}'); close >= 0 {
+		if seq, ok, err := numericRange(s[:close]); ok || err != nil {
+			return seq, s[close+1:], err
+		}
+	}
+
+	sequences := 0
+	for {
+		var seq []string
+		seq, s, err = expandSequence(s, true)
+		if err != nil {
+			return nil, "", err
+		}
+		if s == "" {
+			return nil, "", ErrBadPattern // missing '}'
+		}
+		alts = append(alts, seq...)
+		if len(alts) > maxExpansions {
+			return nil, "", errTooManyExpansions
+		}
+		sequences++
+		if s[0] == '}' {
+			break
+		}
+		s = s[1:] // skip ','
+	}
+
+	if sequences == 1 {
+		// without a comma there is nothing to choose, so keep the braces
+		for i, alt := range alts {
+			alts[i] = "{" + alt + "}"
+		}
+	}
+	return alts, s[1:], nil
+}
+
+// numericRange parses "m..n", where m and n are integers. It reports whether
+// s is a range; the error is set if the range is too long.
+func numericRange(s string) ([]string, bool, error) {
+	lo, hi, ok := strings.Cut(s, "..")
+	if !ok {
+		return nil, false, nil
+	}
+	from, err1 := strconv.Atoi(lo)
+	to, err2 := strconv.Atoi(hi)
+	if err1 != nil || err2 != nil {
+		return nil, false, nil
+	}
+
+	// a negative difference means that it overflowed
+	if diff := max(from, to) - min(from, to); diff < 0 || diff >= maxExpansions {
+		return nil, true, errTooManyExpansions
+	}
+
+	width := 0
+	if hasLeadingZero(lo) || hasLeadingZero(hi) {
+		width = max(len(lo), len(hi))
+	}
+
+	step := 1
+	if to < from {
+		step = -1
+	}
+
+	var seq []string
+	for n := from; ; n += step {
+		seq = append(seq, padded(n, width))
+		if n == to {
+			return seq, true, nil
+		}
+	}
+}
+
+func padded(n, width int) string {
+	s := strconv.Itoa(n)
+	if len(s) >= width {
+		return s
+	}
+	if n < 0 {
+		return "-" + strings.Repeat("0", width-len(s)) + s[1:]
+	}
+	return strings.Repeat("0", width-len(s)) + s
+}
+
+func hasLeadingZero(s string) bool {
+	s = strings.TrimPrefix(s, "-")
+	return len(s) > 1 && s[0] == '0'
+}
+
+func product(heads []string, tail string) []string {
+	if tail == "" {
+		return heads
+	}
+	for i := range heads {
+		heads[i] += tail
+	}
+	return heads
+}
+
+func productAll(heads, tails []string) ([]string, error) {
+	if len(heads)*len(tails) > maxExpansions {
+		return nil, errTooManyExpansions
+	}
+	result := make([]string, 0, len(heads)*len(tails))
+	for _, h := range heads {
+		for _, t := range tails {
+			result = append(result, h+t)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,104 @@
+package path
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestExpand(t *testing.T) {
+	cases := []struct {
+		pattern  string
+		expected []string
+	}{
+		{"/a/b/*.png", []string{"/a/b/*.png"}},
+		{"/img/{small,large}/*.{png,jpg}", []string{
+			"/img/small/*.png", "/img/small/*.jpg", "/img/large/*.png", "/img/large/*.jpg"}},
+		{"/logs/day{1..3}", []string{"/logs/day1", "/logs/day2", "/logs/day3"}},
+		{"/logs/day{3..1}", []string{"/logs/day3", "/logs/day2", "/logs/day1"}},
+		{"/logs/{08..10}", []string{"/logs/08", "/logs/09", "/logs/10"}},
+		{"/x{-1..1}", []string{"/x-1", "/x0", "/x1"}},
+		{"/x{-02..0}", []string{"/x-02", "/x-01", "/x000"}},
+		{"/a/{b,c{d,e}}/f", []string{"/a/b/f", "/a/cd/f", "/a/ce/f"}},
+		{"/a/{b,c/{1..2}}", []string{"/a/b", "/a/c/1", "/a/c/2"}},
+		{"/a{,.bak}", []string{"/a", "/a.bak"}},
+		{"/a/{b}", []string{"/a/{b}"}},
+		{"/a/{}", []string{"/a/{}"}},
+		{"/a/{x..y}", []string{"/a/{x..y}"}},
+		{`/a/\{b,c\}`, []string{`/a/\{b,c\}`}},
+		{`/a/{b\,c,d}`, []string{`/a/b\,c`, "/a/d"}},
+		{"/a,b", []string{"/a,b"}},
+		{"/a/[{]", []string{"/a/[{]"}},
+		{"/a/[,}]", []string{"/a/[,}]"}},
+		{"/a/[^{}]{b,c}", []string{"/a/[^{}]b", "/a/[^{}]c"}},
+		{"/a/{[,],d}", []string{"/a/[,]", "/a/d"}},
+		{`/a/[\]{]`, []string{`/a/[\]{]`}},
+	}
+
+	for _, c := range cases {
+		actual, err := Expand(c.pattern)
+		isNil(t, err, c.pattern)
+		isEqual(t, actual, c.expected, c.pattern)
+	}
+}
+
+func TestExpandUnbalanced(t *testing.T) {
+	for _, pattern := range []string{"/a/{b,c", "/a/b,c}", "/a/{b,{c}", "/a/}{", "/a/{"} {
+		_, err := Expand(pattern)
+		isEqual(t, err, ErrBadPattern, pattern)
+
+		_, err = MatchDeep(pattern, "/a/b")
+		isEqual(t, err, ErrBadPattern, pattern)
+	}
+}
+
+func TestExpandTooMany(t *testing.T) {
+	for _, pattern := range []string{
+		"{1..3000000}",
+		"{-9223372036854775807..9223372036854775807}",
+		"{1..200}/{1..200}",
+		"{a,b}{c,d}{e,f}{g,h}{i,j}{k,l}{m,n}{o,p}{q,r}{s,t}{u,v}{w,x}{y,z}{0,1}",
+		"{{1..9000},{1..9000}}",
+	} {
+		_, err := Expand(pattern)
+		isEqual(t, errors.Is(err, ErrBadPattern), true, pattern)
+		isEqual(t, err.Error(), "syntax error in pattern: more than 10000 brace expansions", pattern)
+
+		_, err = Compile(pattern)
+		isEqual(t, errors.Is(err, ErrBadPattern), true, pattern)
+
+		_, err = NewPatternSet(pattern)
+		isEqual(t, errors.Is(err, ErrBadPattern), true, pattern)
+	}
+
+	seq, err := Expand("{1..10000}")
+	isNil(t, err, "")
+	isEqual(t, len(seq), 10000, "")
+}
+
+func TestMatchDeepWithBraces(t *testing.T) {
+	cases := []struct {
+		pattern, name string
+		expected      bool
+	}{
+		{"/img/{small,large}/*.{png,jpg}", "/img/large/x.jpg", true},
+		{"/img/{small,large}/*.{png,jpg}", "/img/medium/x.jpg", false},
+		{"/logs/day{1..31}", "/logs/day17", true},
+		{"/logs/day{1..31}", "/logs/day32", false},
+		{"/{a,b/**}/x", "/b/c/d/x", true},
+		{"/{a,b/**}/x", "/a/c/x", false},
+		{"/a/[{]", "/a/{", true},
+		{"/a/[,}]", "/a/}", true},
+		{"/{a,b}/[{]", "/b/{", true},
+	}
+
+	for _, c := range cases {
+		matched, err := MatchDeep(c.pattern, c.name)
+		isNil(t, err, c)
+		isEqual(t, matched, c.expected, c)
+	}
+
+	set, err := NewPatternSet("/img/{small,large}/*.{png,jpg}", "/img/*/*")
+	isNil(t, err, "")
+	isEqual(t, set.Matches("/img/small/x.png"), []int{0, 1}, "")
+	isEqual(t, MustCompile("/img/{small,large}/*").Prefix(), "/img/", "")
+}
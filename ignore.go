@@ -0,0 +1,130 @@
+package path
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+)
+
+// Ignore is an ordered list of include and exclude rules in the style of
+// .gitignore and .dockerignore files. It decides whether a path is ignored;
+// where several rules match a path, the last one wins.
+//
+// An Ignore is safe for concurrent use.
+type Ignore struct {
+	rules []ignoreRule
+}
+
+type ignoreRule struct {
+	pattern *Pattern
+	negate  bool // '!' re-includes the path
+	dirOnly bool // trailing '/' matches only directories
+}
+
+// ParseIgnore parses rule text, one rule per line, following the .gitignore format:
+//
+//   - Blank lines are ignored, as are lines starting with '#'.
+//   - Trailing spaces are ignored unless escaped with a backslash.
+//   - A leading '!' negates the rule, so that a path excluded by an earlier
+//     rule is included again. This does not work for a path whose parent
+//     directory is excluded.
+//   - A trailing '/' makes the rule match only directories.
+//   - A rule containing a '/' at the beginning or in the middle is anchored, i.e.
+//     it is relative to the root. Otherwise it can match at any level.
+//   - '*', '?', '[...]' and '**' are as for MatchDeep, except that a trailing
+//     "/**" matches everything inside a directory but not the directory itself.
+//     Braces have no special meaning.
+//   - A leading backslash escapes '#' or '!'.
+//
+// If any rule is malformed, the error wraps ErrBadPattern and gives the line number.
+func ParseIgnore(text string) (*Ignore, error) {
+	ig := &Ignore{}
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for line := 1; scanner.Scan(); line++ {
+		rule, ok, err := parseIgnoreRule(scanner.Text())
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w: %q", line, err, scanner.Text())
+		}
+		if ok {
+			ig.rules = append(ig.rules, rule)
+		}
+	}
+	return ig, scanner.Err()
+}
+
+func parseIgnoreRule(line string) (rule ignoreRule, ok bool, err error) {
+	line = strings.TrimSuffix(line, "\r")
+	for strings.HasSuffix(line, " ") && !strings.HasSuffix(line, `\ `) {
+		line = line[:len(line)-1]
+	}
+
+	if line == "" || line[0] == '#' {
+		return ignoreRule{}, false, nil
+	}
+
+	if line[0] == '!' {
+		rule.negate = true
+		line = line[1:]
+	}
+
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+
+	if line == "" {
+		return ignoreRule{}, false, nil
+	}
+
+	if strings.Contains(line, "/") {
+		line = strings.TrimPrefix(line, "/")
+	} else {
+		line = "**/" + line
+	}
+
+	if rest, ok := strings.CutSuffix(line, "/**"); ok {
+		line = rest + "/*/**" // excludes the directory itself
+	}
+
+	rule.pattern, err = compileExpanded(line, []string{line})
+	return rule, err == nil, err
+}
+
+// Ignored reports whether a path is ignored by the rules. The path is treated
+// as relative to the root of the rules, whether or not it has a leading slash.
+// The isDir flag indicates whether the path refers to a directory; it is
+// needed by rules that end with a slash.
+//
+// A path is ignored if any of its parent directories is ignored; otherwise
+// the last rule that matches the path decides.
+func (ig *Ignore) Ignored(path Path, isDir bool) bool {
+	path = path.Clean()
+	if path == "." || path == "/" {
+		return false
+	}
+	path = Path(strings.TrimPrefix(string(path), "/"))
+
+	for parent := range path.Ancestors() {
+		if len(parent) == len(path) {
+			break
+		}
+		if ig.match(parent, true) {
+			return true
+		}
+	}
+
+	return ig.match(path, isDir)
+}
+
+func (ig *Ignore) match(path Path, isDir bool) bool {
+	ignored := false
+	for _, rule := range ig.rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+		if rule.negate == ignored && rule.pattern.Match(path) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
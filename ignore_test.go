@@ -0,0 +1,83 @@
+package path
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIgnore(t *testing.T) {
+	ig, err := ParseIgnore(`
+# build output
+/bin/
+*.log
+!keep.log
+build/**
+!build/README
+docs/**/*.tmp
+node_modules/
+\#hash
+\!bang
+trailing   
+escaped\ 
+`)
+	isNil(t, err, "")
+
+	cases := []struct {
+		path     Path
+		isDir    bool
+		expected bool
+	}{
+		{"/bin", true, true},
+		{"/bin", false, false},
+		{"/bin/tool", false, true},
+		{"/src/bin", true, false},
+		{"/a.log", false, true},
+		{"/src/deep/a.log", false, true},
+		{"/src/keep.log", false, false},
+		{"/build", true, false},
+		{"/build/x/y.o", false, true},
+		{"/build/README", false, false},
+		{"/docs/a.tmp", false, true},
+		{"/docs/a/b/c.tmp", false, true},
+		{"/src/docs/a.tmp", false, false},
+		{"/node_modules", true, true},
+		{"/a/node_modules/x/y.js", false, true},
+		{"/a/node_modules", false, false},
+		{"/#hash", false, true},
+		{"/!bang", false, true},
+		{"/trailing", false, true},
+		{"/escaped ", false, true},
+		{"/escaped", false, false},
+		{"src/main.go", false, false},
+		{"a//b/../c.log", false, true},
+		{"/", true, false},
+		{"", false, false},
+	}
+
+	for _, c := range cases {
+		isEqual(t, ig.Ignored(c.path, c.isDir), c.expected, c)
+	}
+}
+
+func TestIgnoreCannotReincludeBelowExcludedDir(t *testing.T) {
+	ig, err := ParseIgnore("logs/\n!logs/important.log\n")
+	isNil(t, err, "")
+	isEqual(t, ig.Ignored("/logs/important.log", false), true, "")
+}
+
+func TestIgnoreLastMatchWins(t *testing.T) {
+	ig, err := ParseIgnore("*.txt\n!a.txt\na.txt\n")
+	isNil(t, err, "")
+	isEqual(t, ig.Ignored("/a.txt", false), true, "")
+	isEqual(t, ig.Ignored("/b.txt", false), true, "")
+
+	ig, err = ParseIgnore("*.txt\n!a.txt\n")
+	isNil(t, err, "")
+	isEqual(t, ig.Ignored("/a.txt", false), false, "")
+}
+
+func TestIgnoreBadPattern(t *testing.T) {
+	_, err := ParseIgnore("*.log\n[a-\n")
+	isEqual(t, errors.Is(err, ErrBadPattern), true, "")
+	isEqual(t, err.Error(), `line 2: syntax error in pattern: "[a-"`, "")
+}
@@ -0,0 +1,149 @@
+package path
+
+import (
+	"iter"
+	"strings"
+)
+
+// trimmed returns the part of the path that Segments would split, i.e. without
+// any leading or trailing slash, along with the offset of that part within path.
+func (path Path) trimmed() (string, int) {
+	if path == "/" || path == "" {
+		return "", 0
+	}
+	s, start := string(path), 0
+	if s[0] == '/' {
+		s = s[1:]
+		start = 1
+	}
+	if strings.HasSuffix(s, "/") {
+		s = s[:len(s)-1]
+	}
+	return s, start
+}
+
+// All returns an iterator over the path segments, yielding the index and the segment.
+// The segments are the same as those returned by Segments but no slice is allocated.
+func (path Path) All() iter.Seq2[int, string] {
+	return func(yield func(int, string) bool) {
+		if path == "" || path == "/" {
+			return
+		}
+		s, _ := path.trimmed()
+		for i := 0; ; i++ {
+			slash := strings.IndexByte(s, '/')
+			if slash < 0 {
+				yield(i, s)
+				return
+			}
+			if !yield(i, s[:slash]) {
+				return
+			}
+			s = s[slash+1:]
+		}
+	}
+}
+
+// Values returns an iterator over the path segments. The segments are the
+// same as those returned by Segments but no slice is allocated.
+func (path Path) Values() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for _, seg := range path.All() {
+			if !yield(seg) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the path segments from right to left,
+// yielding the index and the segment. The indexes are the same as those
+// yielded by All, so they count down to zero.
+func (path Path) Backward() iter.Seq2[int, string] {
+	return func(yield func(int, string) bool) {
+		if path == "" || path == "/" {
+			return
+		}
+		s, _ := path.trimmed()
+		i := strings.Count(s, "/")
+		for ; ; i-- {
+			slash := strings.LastIndexByte(s, '/')
+			if slash < 0 {
+				yield(i, s)
+				return
+			}
+			if !yield(i, s[slash+1:]) {
+				return
+			}
+			s = s[:slash]
+		}
+	}
+}
+
+// Ancestors returns an iterator over the successively longer paths that lead
+// to this path, one segment at a time. For example, "/a/b/c" yields "/a", "/a/b"
+// and "/a/b/c". A leading slash is kept; a trailing slash is not. The root path
+// "/" and the empty path yield nothing. Each value is a sub-slice of the original
+// path so no copying is involved.
+func (path Path) Ancestors() iter.Seq[Path] {
+	return func(yield func(Path) bool) {
+		if path == "" || path == "/" {
+			return
+		}
+		s, start := path.trimmed()
+		end := start
+		for {
+			slash := strings.IndexByte(s[end-start:], '/')
+			if slash < 0 {
+				yield(path[:start+len(s)])
+				return
+			}
+			end += slash
+			if !yield(path[:end]) {
+				return
+			}
+			end++
+		}
+	}
+}
+
+// Descendants returns an iterator similar to Ancestors, except that only the paths
+// that are longer than prefix are yielded. For example, "/a/b/c/d" with prefix "/a/b"
+// yields "/a/b/c" and "/a/b/c/d".
+//
+// The prefix is compared segment by segment, so "/a/bc" does not have the
+// prefix "/a/b". If the path does not have the prefix, nothing is yielded.
+// The prefix is Cleaned first, as for IsWithin, but the path is not. A blank
+// prefix or "." matches any path; the prefix "/" matches any absolute path.
+func (path Path) Descendants(prefix Path) iter.Seq[Path] {
+	if prefix != "" {
+		prefix = prefix.Clean()
+		if prefix == "." {
+			prefix = ""
+		}
+	}
+
+	return func(yield func(Path) bool) {
+		if prefix.IsAbs() != path.IsAbs() && !prefix.IsEmpty() {
+			return
+		}
+
+		ps, _ := prefix.trimmed()
+		s, _ := path.trimmed()
+		depth := 0
+		if ps != "" {
+			if !strings.HasPrefix(s, ps) || (len(s) > len(ps) && s[len(ps)] != '/') {
+				return
+			}
+			depth = strings.Count(ps, "/") + 1
+		}
+
+		for p := range path.Ancestors() {
+			if depth > 0 {
+				depth--
+			} else if !yield(p) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package path
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPathAll(t *testing.T) {
+	cases := []Path{"/a/b/c/zz.png", "a/b/c/zz.png", "/a/b/c/", "/a//b", "//", "/", ""}
+
+	for _, p := range cases {
+		var indexes []int
+		var segments []string
+		for i, s := range p.All() {
+			indexes = append(indexes, i)
+			segments = append(segments, s)
+		}
+		isEqual(t, segments, p.Segments(), p)
+		for i, n := range indexes {
+			isEqual(t, n, i, p)
+		}
+
+		isEqual(t, slices.Collect(p.Values()), p.Segments(), p)
+	}
+}
+
+func TestPathAllStopsEarly(t *testing.T) {
+	var segments []string
+	for _, s := range Path("/a/b/c").All() {
+		segments = append(segments, s)
+		if s == "b" {
+			break
+		}
+	}
+	isEqual(t, segments, []string{"a", "b"}, "")
+}
+
+func TestPathBackward(t *testing.T) {
+	var indexes []int
+	var segments []string
+	for i, s := range Path("/a/b/c/").Backward() {
+		indexes = append(indexes, i)
+		segments = append(segments, s)
+	}
+	isEqual(t, indexes, []int{2, 1, 0}, "")
+	isEqual(t, segments, []string{"c", "b", "a"}, "")
+
+	for range Path("/").Backward() {
+		t.Errorf("unexpected segment")
+	}
+}
+
+func TestPathAncestors(t *testing.T) {
+	cases := []struct {
+		input    Path
+		expected []Path
+	}{
+		{"/a/b/c", []Path{"/a", "/a/b", "/a/b/c"}},
+		{"a/b/c", []Path{"a", "a/b", "a/b/c"}},
+		{"/a/b/c/", []Path{"/a", "/a/b", "/a/b/c"}},
+		{"/a", []Path{"/a"}},
+		{"/", nil},
+		{"", nil},
+	}
+
+	for _, c := range cases {
+		isEqual(t, slices.Collect(c.input.Ancestors()), c.expected, c.input)
+	}
+}
+
+func TestPathDescendants(t *testing.T) {
+	cases := []struct {
+		input, prefix Path
+		expected      []Path
+	}{
+		{"/a/b/c/d", "/a/b", []Path{"/a/b/c", "/a/b/c/d"}},
+		{"/a/b/c/d", "/a/b/", []Path{"/a/b/c", "/a/b/c/d"}},
+		{"/a/b/c/d", "/", []Path{"/a", "/a/b", "/a/b/c", "/a/b/c/d"}},
+		{"/a/b/c/d", "", []Path{"/a", "/a/b", "/a/b/c", "/a/b/c/d"}},
+		{"a/b/c", "a", []Path{"a/b", "a/b/c"}},
+		{"/a/b/c", "/a/b/c", nil},
+		{"/a/bc/d", "/a/b", nil},
+		{"/a/b/c", "a/b", nil},
+		{"a/b/c", "/", nil},
+		{"a/b", ".", []Path{"a", "a/b"}},
+		{"a/b", "./a", []Path{"a/b"}},
+		{"/a/b/c", "/a//b", []Path{"/a/b/c"}},
+		{"/a/b/c", "/a/x/../b/", []Path{"/a/b/c"}},
+	}
+
+	for _, c := range cases {
+		isEqual(t, slices.Collect(c.input.Descendants(c.prefix)), c.expected, c)
+	}
+}
+
+func BenchmarkPathAll(b *testing.B) {
+	p := Path("/a/b/c/d/e/f/g/zz.png")
+	b.ReportAllocs()
+	for b.Loop() {
+		for range p.All() {
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package path
+
+// MatchDeep reports whether name matches the shell file name pattern.
+// It is the same as Match except that the pattern may also contain the term
+//
+//	'**'        matches zero or more whole path segments
+//
+// which must form an entire segment, i.e. it must be delimited by slashes
+// or the ends of the pattern. Elsewhere, '**' is the same as '*'.
+//
+// Brace terms are also allowed, e.g. "/img/{small,large}/*.{png,jpg}" and
+// "/logs/day{1..31}"; see Expand for details. The name matches if it matches
+// any of the alternatives.
+//
+// So "/assets/**/*.png" matches "/assets/x.png", "/assets/a/x.png" and
+// "/assets/a/b/x.png". The leading slash of an absolute name counts as an
+// empty first segment, so a pattern starting with '**' matches both absolute
+// and relative names.
+//
+// MatchDeep requires pattern to match all of name, not just a substring.
+// The returned error is ErrBadPattern when pattern is malformed, or wraps
+// ErrBadPattern when its brace terms expand too far (see Expand).
+//
+// When the same pattern is used repeatedly, Compile is more efficient.
+func MatchDeep(pattern, name string) (matched bool, err error) {
+	p, err := compile(pattern)
+	if err != nil {
+		return false, err
+	}
+	return p.Match(Path(name)), nil
+}
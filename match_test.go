@@ -0,0 +1,50 @@
+package path
+
+import "testing"
+
+func TestMatchDeep(t *testing.T) {
+	cases := []struct {
+		pattern, name string
+		expected      bool
+	}{
+		{"/a/b/*.png", "/a/b/zz.png", true},
+		{"/a/*/*.png", "/a/b/c/zz.png", false},
+		{"/assets/**/*.png", "/assets/x.png", true},
+		{"/assets/**/*.png", "/assets/a/x.png", true},
+		{"/assets/**/*.png", "/assets/a/b/c/x.png", true},
+		{"/assets/**/*.png", "/assets/a/b/c/x.jpg", false},
+		{"/assets/**/*.png", "/other/a/x.png", false},
+		{"/assets/**", "/assets", true},
+		{"/assets/**", "/assets/a/b", true},
+		{"/assets/**/**/x", "/assets/x", true},
+		{"/a/**/b/**/c", "/a/x/b/y/z/c", true},
+		{"/a/**/b/**/c", "/a/x/y/z/c", false},
+		{"**/*.png", "/a/x.png", true},
+		{"**/*.png", "a/x.png", true},
+		{"**", "", true},
+		{"/a/x**y/c", "/a/xzzy/c", true},
+		{"/a/x**y/c", "/a/x/y/c", false},
+		{"/a/[a-c]/**", "/a/b/q", true},
+		{"/a/[^a-c]/**", "/a/b/q", false},
+		{"/a/\\*/b", "/a/*/b", true},
+	}
+
+	for _, c := range cases {
+		matched, err := MatchDeep(c.pattern, c.name)
+		isNil(t, err, c)
+		isEqual(t, matched, c.expected, c)
+	}
+}
+
+func TestMatchDeepBadPattern(t *testing.T) {
+	for _, pattern := range []string{"/a/[", "/a/**/[b-", "/a/b/\\", "/x/**/[]"} {
+		_, err := MatchDeep(pattern, "/z")
+		isEqual(t, err, ErrBadPattern, pattern)
+	}
+}
+
+func TestPathMatch(t *testing.T) {
+	a, e := Path("/a/b/c/zz.png").Match("/a/**/*.png")
+	isEqual(t, a, true, "")
+	isNil(t, e, "")
+}
@@ -0,0 +1,421 @@
+package path
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// MuxPattern is a pattern in the syntax used by net/http.ServeMux since Go 1.22:
+//
+//	[METHOD ][HOST]/[PATH]
+//
+// For example, "GET example.com/items/{id}". The path may contain wildcards
+// that are whole segments:
+//
+//	{name}      matches one segment
+//	{name...}   matches the rest of the path; it must be the last segment
+//	{$}         matches only the end of a path ending in a slash
+//
+// A path ending in a slash, without {$}, matches any path with that prefix.
+//
+// MuxPattern allows routing tables to be checked and introspected without
+// needing a ServeMux. It is safe for concurrent use.
+type MuxPattern struct {
+	source   string
+	method   string
+	host     string
+	segments []muxSegment
+}
+
+type muxSegment struct {
+	s     string // the literal, or the wildcard name; "/" stands for {$}
+	wild  bool
+	multi bool // the segment matches the rest of the path
+}
+
+// ParseMuxPattern parses a ServeMux pattern. It applies the same rules as
+// ServeMux. If the pattern is malformed, the error is a *TemplateError.
+func ParseMuxPattern(pattern string) (*MuxPattern, error) {
+	fail := func(offset int, message string) (*MuxPattern, error) {
+		return nil, &TemplateError{Template: pattern, Offset: offset, Message: message}
+	}
+
+	if pattern == "" {
+		return fail(0, "empty pattern")
+	}
+
+	p := &MuxPattern{source: pattern}
+	rest, off := pattern, 0
+	if i := strings.IndexAny(pattern, " \t"); i >= 0 {
+		p.method = pattern[:i]
+		rest = strings.TrimLeft(pattern[i+1:], " \t")
+		off = len(pattern) - len(rest)
+		if !isToken(p.method) {
+			return fail(0, fmt.Sprintf("invalid method %q", p.method))
+		}
+	}
+
+	slash := strings.IndexByte(rest, '/')
+	if slash < 0 {
+		return fail(off, "host/path missing /")
+	}
+	p.host, rest = rest[:slash], rest[slash:]
+	if strings.IndexByte(p.host, '{') >= 0 {
+		return fail(off, "host contains '{' (missing initial '/'?)")
+	}
+	off += slash
+
+	if p.method != "" && p.method != "CONNECT" && rest != cleanMuxPath(rest) {
+		return fail(off, "non-CONNECT pattern with unclean path can never match")
+	}
+
+	names := make(map[string]bool)
+	for len(rest) > 0 {
+		rest = rest[1:] // drop the slash
+		off++
+
+		if rest == "" {
+			// a trailing slash matches anything that follows
+			p.segments = append(p.segments, muxSegment{wild: true, multi: true})
+			break
+		}
+
+		end := strings.IndexByte(rest, '/')
+		if end < 0 {
+			end = len(rest)
+		}
+		seg, tail := rest[:end], rest[end:]
+
+		brace := strings.IndexByte(seg, '{')
+		if brace < 0 {
+			value, err := url.PathUnescape(seg)
+			if err != nil {
+				return fail(off, "invalid escape in literal segment")
+			}
+			p.segments = append(p.segments, muxSegment{s: value})
+			rest, off = tail, off+len(seg)
+			continue
+		}
+
+		if brace != 0 {
+			return fail(off, "bad wildcard segment (must start with '{')")
+		}
+		if seg[len(seg)-1] != '}' {
+			return fail(off, "bad wildcard segment (must end with '}')")
+		}
+
+		name := seg[1 : len(seg)-1]
+		if name == "$" {
+			if tail != "" {
+				return fail(off, "{$} not at end")
+			}
+			p.segments = append(p.segments, muxSegment{s: "/"})
+			break
+		}
+
+		name, multi := strings.CutSuffix(name, "...")
+		if multi && tail != "" {
+			return fail(off, "{...} wildcard not at end")
+		}
+		if name == "" {
+			return fail(off, "empty wildcard")
+		}
+		if !isParamName(name) || (name[0] >= '0' && name[0] <= '9') {
+			return fail(off, fmt.Sprintf("bad wildcard name %q", name))
+		}
+		if names[name] {
+			return fail(off, fmt.Sprintf("duplicate wildcard name %q", name))
+		}
+		names[name] = true
+
+		p.segments = append(p.segments, muxSegment{s: name, wild: true, multi: multi})
+		rest, off = tail, off+len(seg)
+	}
+
+	return p, nil
+}
+
+// MustParseMuxPattern is like ParseMuxPattern but panics if the pattern is malformed.
+func MustParseMuxPattern(pattern string) *MuxPattern {
+	p, err := ParseMuxPattern(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// isToken reports whether s is an HTTP token, as required for a method.
+func isToken(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range []byte(s) {
+		if c <= ' ' || c >= 0x7f || strings.IndexByte(`"(),/:;<=>?@[\]{}`, c) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// cleanMuxPath cleans a path in the same way as ServeMux, i.e. like Clean but
+// keeping a trailing slash.
+func cleanMuxPath(p string) string {
+	if p == "" {
+		return "/"
+	}
+	if p[0] != '/' {
+		p = "/" + p
+	}
+	np := Clean(p)
+	if p[len(p)-1] == '/' && np != "/" {
+		np += "/"
+	}
+	return np
+}
+
+// Method returns the method of the pattern, or "" if it matches any method.
+func (p *MuxPattern) Method() string {
+	return p.method
+}
+
+// Host returns the host of the pattern, or "" if it matches any host.
+func (p *MuxPattern) Host() string {
+	return p.host
+}
+
+// String returns the source text of the pattern.
+func (p *MuxPattern) String() string {
+	return p.source
+}
+
+// Match reports whether a request with the given method, host and path would
+// match the pattern and, if so, returns the values of the named wildcards.
+//
+// As in ServeMux, a pattern with method GET also matches HEAD requests. Any
+// port is removed from the host before it is compared exactly, so the case of
+// the host matters. Each segment of the path is unescaped before it is compared
+// with a literal or captured by a wildcard.
+func (p *MuxPattern) Match(method, host string, path Path) (Params, bool) {
+	if p.method != "" && p.method != method && !(p.method == "GET" && method == "HEAD") {
+		return nil, false
+	}
+
+	if p.host != "" {
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if p.host != host {
+			return nil, false
+		}
+	}
+
+	var params Params
+	rest := string(path)
+	for _, seg := range p.segments {
+		if !strings.HasPrefix(rest, "/") {
+			return nil, false
+		}
+
+		switch {
+		case seg.multi:
+			if seg.s != "" {
+				params = append(params, Param{Name: seg.s, Value: unescapeMux(rest[1:])})
+			}
+			return params, true
+
+		case seg.s == "/" && !seg.wild:
+			return params, rest == "/"
+		}
+
+		head, _, _ := strings.Cut(rest[1:], "/")
+		value := unescapeMux(head)
+		if seg.wild {
+			if head == "" {
+				return nil, false
+			}
+			params = append(params, Param{Name: seg.s, Value: value})
+		} else if value != seg.s {
+			return nil, false
+		}
+		rest = rest[1+len(head):]
+	}
+
+	if rest != "" {
+		return nil, false
+	}
+	return params, true
+}
+
+func unescapeMux(s string) string {
+	if u, err := url.PathUnescape(s); err == nil {
+		return u
+	}
+	return s
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// relationship is the way two patterns compare in terms of the requests they match.
+type relationship uint8
+
+const (
+	equivalent   relationship = iota // both match the same requests
+	moreGeneral                      // matches a strict superset of the other's requests
+	moreSpecific                     // matches a strict subset of the other's requests
+	overlaps                         // some requests match both, but neither is a subset
+	disjoint                         // no requests match both
+)
+
+func (r relationship) inverse() relationship {
+	switch r {
+	case moreGeneral:
+		return moreSpecific
+	case moreSpecific:
+		return moreGeneral
+	}
+	return r
+}
+
+// MoreSpecificThan reports whether p takes precedence over q when both match a
+// request. As in ServeMux, a pattern with a host is more specific than one
+// without; otherwise p is more specific if it matches a strict subset of the
+// requests that q matches.
+func (p *MuxPattern) MoreSpecificThan(q *MuxPattern) bool {
+	if (p.host == "") != (q.host == "") {
+		return p.host != ""
+	}
+	return p.compareMethodsAndPaths(q) == moreSpecific
+}
+
+// ConflictsWith reports whether p and q conflict, i.e. they have the same host
+// and some requests would match both but neither is more specific. ServeMux
+// refuses to register a pattern that conflicts with one already registered.
+func (p *MuxPattern) ConflictsWith(q *MuxPattern) bool {
+	if p.host != q.host {
+		return false
+	}
+	rel := p.compareMethodsAndPaths(q)
+	return rel == equivalent || rel == overlaps
+}
+
+func (p *MuxPattern) compareMethodsAndPaths(q *MuxPattern) relationship {
+	mrel := p.compareMethods(q)
+	if mrel == disjoint {
+		return disjoint
+	}
+	return combineRelationships(mrel, p.comparePaths(q))
+}
+
+func (p *MuxPattern) compareMethods(q *MuxPattern) relationship {
+	switch {
+	case p.method == q.method:
+		return equivalent
+	case p.method == "":
+		return moreGeneral
+	case q.method == "":
+		return moreSpecific
+	case p.method == "GET" && q.method == "HEAD":
+		return moreGeneral
+	case p.method == "HEAD" && q.method == "GET":
+		return moreSpecific
+	}
+	return disjoint
+}
+
+func (p *MuxPattern) comparePaths(q *MuxPattern) relationship {
+	pMulti, qMulti := p.lastSegment().multi, q.lastSegment().multi
+	if len(p.segments) != len(q.segments) && !pMulti && !qMulti {
+		return disjoint
+	}
+
+	ps, qs := p.segments, q.segments
+	rel := equivalent
+	for ; len(ps) > 0 && len(qs) > 0; ps, qs = ps[1:], qs[1:] {
+		rel = combineRelationships(rel, compareMuxSegments(ps[0], qs[0]))
+		if rel == disjoint {
+			return rel
+		}
+	}
+
+	switch {
+	case len(ps) == 0 && len(qs) == 0:
+		return rel
+	case len(ps) < len(qs) && pMulti:
+		return combineRelationships(rel, moreGeneral)
+	case len(qs) < len(ps) && qMulti:
+		return combineRelationships(rel, moreSpecific)
+	}
+	return disjoint
+}
+
+func (p *MuxPattern) lastSegment() muxSegment {
+	return p.segments[len(p.segments)-1]
+}
+
+func compareMuxSegments(a, b muxSegment) relationship {
+	switch {
+	case a.multi && b.multi:
+		return equivalent
+	case a.multi:
+		return moreGeneral
+	case b.multi:
+		return moreSpecific
+	case a.wild && b.wild:
+		return equivalent
+	case a.wild:
+		if b.s == "/" {
+			return disjoint // {$} never matches a wildcard
+		}
+		return moreGeneral
+	case b.wild:
+		if a.s == "/" {
+			return disjoint
+		}
+		return moreSpecific
+	case a.s == b.s:
+		return equivalent
+	}
+	return disjoint
+}
+
+func combineRelationships(r1, r2 relationship) relationship {
+	switch r1 {
+	case equivalent:
+		return r2
+	case disjoint:
+		return disjoint
+	case overlaps:
+		if r2 == disjoint {
+			return disjoint
+		}
+		return overlaps
+	}
+
+	// r1 is moreGeneral or moreSpecific
+	switch r2 {
+	case equivalent:
+		return r1
+	case r1.inverse():
+		return overlaps
+	}
+	return r2
+}
+
+// SelectMuxPattern finds the pattern that ServeMux would choose for a request,
+// i.e. the most specific of the patterns that match. It returns the index of
+// that pattern and the values of its wildcards, or -1 if none match. If the
+// patterns conflict, the result is the first of the conflicting patterns.
+func SelectMuxPattern(patterns []*MuxPattern, method, host string, path Path) (int, Params) {
+	best := -1
+	var bestParams Params
+	for i, p := range patterns {
+		if params, ok := p.Match(method, host, path); ok {
+			if best < 0 || p.MoreSpecificThan(patterns[best]) {
+				best, bestParams = i, params
+			}
+		}
+	}
+	return best, bestParams
+}
@@ -0,0 +1,142 @@
+package path
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseMuxPattern(t *testing.T) {
+	p := MustParseMuxPattern("GET example.com/items/{id}")
+	isEqual(t, p.Method(), "GET", "")
+	isEqual(t, p.Host(), "example.com", "")
+	isEqual(t, p.String(), "GET example.com/items/{id}", "")
+
+	p = MustParseMuxPattern("/items/")
+	isEqual(t, p.Method(), "", "")
+	isEqual(t, p.Host(), "", "")
+}
+
+func TestParseMuxPatternErrors(t *testing.T) {
+	cases := []struct {
+		pattern, message string
+	}{
+		{"", "empty pattern"},
+		{"GET", "host/path missing /"},
+		{"G@T /x", `invalid method "G@T"`},
+		{"{x}/a", "host contains '{' (missing initial '/'?)"},
+		{"GET /a/../b", "non-CONNECT pattern with unclean path can never match"},
+		{"/a/x{id}", "bad wildcard segment (must start with '{')"},
+		{"/a/{id}x", "bad wildcard segment (must end with '}')"},
+		{"/a/{$}/b", "{$} not at end"},
+		{"/a/{x...}/b", "{...} wildcard not at end"},
+		{"/a/{}", "empty wildcard"},
+		{"/a/{1x}", `bad wildcard name "1x"`},
+		{"/a/{x}/{x}", `duplicate wildcard name "x"`},
+	}
+
+	for _, c := range cases {
+		_, err := ParseMuxPattern(c.pattern)
+		var te *TemplateError
+		isEqual(t, errors.As(err, &te), true, c.pattern)
+		isEqual(t, te.Message, c.message, c.pattern)
+	}
+}
+
+func TestMuxPatternMatch(t *testing.T) {
+	cases := []struct {
+		pattern, method, host string
+		path                  Path
+		params                Params
+		ok                    bool
+	}{
+		{"/items/{id}", "GET", "x.com", "/items/42", Params{{"id", "42"}}, true},
+		{"/items/{id}", "GET", "x.com", "/items/42/", nil, false},
+		{"/items/{id}", "GET", "x.com", "/items/", nil, false},
+		{"/items/{id}", "GET", "x.com", "/items/a%2Fb", Params{{"id", "a/b"}}, true},
+		{"GET /items/{id}", "HEAD", "x.com", "/items/42", Params{{"id", "42"}}, true},
+		{"GET /items/{id}", "POST", "x.com", "/items/42", nil, false},
+		{"example.com/a", "GET", "example.com:8080", "/a", nil, true},
+		{"example.com/a", "GET", "other.com", "/a", nil, false},
+		{"example.com/a", "GET", "Example.com", "/a", nil, false},
+		{"/files/{rest...}", "GET", "", "/files/a/b%20c", Params{{"rest", "a/b c"}}, true},
+		{"/files/{rest...}", "GET", "", "/files/", Params{{"rest", ""}}, true},
+		{"/files/{rest...}", "GET", "", "/files", nil, false},
+		{"/files/", "GET", "", "/files/a/b", nil, true},
+		{"/files/", "GET", "", "/files", nil, false},
+		{"/files/{$}", "GET", "", "/files/", nil, true},
+		{"/files/{$}", "GET", "", "/files/a", nil, false},
+		{"/{$}", "GET", "", "/", nil, true},
+		{"/", "GET", "", "/anything/at/all", nil, true},
+		{"/a%20b", "GET", "", "/a%20b", nil, true},
+	}
+
+	for _, c := range cases {
+		params, ok := MustParseMuxPattern(c.pattern).Match(c.method, c.host, c.path)
+		isEqual(t, ok, c.ok, c)
+		isEqual(t, params, c.params, c)
+	}
+}
+
+func TestMuxPatternPrecedence(t *testing.T) {
+	cases := []struct {
+		p1, p2              string
+		moreSpecific, clash bool
+	}{
+		{"/items/{id}", "/items/", true, false},
+		{"/items/new", "/items/{id}", true, false},
+		{"GET /items/{id}", "/items/{id}", true, false},
+		{"HEAD /items/{id}", "GET /items/{id}", true, false},
+		{"example.com/", "/items/new", true, false},
+		{"/items/{$}", "/items/", true, false},
+		{"/items/{id}", "/items/{name}", false, true},
+		{"/a/{x}", "/{y}/b", false, true},
+		{"GET /a", "POST /a", false, false},
+		{"/a/{$}", "/a/{x}", false, false},
+		{"/a/{rest...}", "/a/", false, true},
+	}
+
+	for _, c := range cases {
+		p1, p2 := MustParseMuxPattern(c.p1), MustParseMuxPattern(c.p2)
+		isEqual(t, p1.MoreSpecificThan(p2), c.moreSpecific, c)
+		isEqual(t, p2.MoreSpecificThan(p1), false, c)
+		isEqual(t, p1.ConflictsWith(p2), c.clash, c)
+		isEqual(t, p2.ConflictsWith(p1), c.clash, c)
+	}
+}
+
+func TestSelectMuxPattern(t *testing.T) {
+	var patterns []*MuxPattern
+	for _, s := range []string{"/", "/items/", "/items/{id}", "GET /items/{id}", "GET /items/new", "example.com/items/"} {
+		patterns = append(patterns, MustParseMuxPattern(s))
+	}
+
+	cases := []struct {
+		method, host string
+		path         Path
+		index        int
+		params       Params
+	}{
+		{"GET", "", "/x", 0, nil},
+		{"GET", "", "/items/a/b", 1, nil},
+		{"POST", "", "/items/42", 2, Params{{"id", "42"}}},
+		{"GET", "", "/items/42", 3, Params{{"id", "42"}}},
+		{"GET", "", "/items/new", 4, nil},
+		{"POST", "", "/items/new", 2, Params{{"id", "new"}}},
+		{"GET", "example.com", "/items/new", 5, nil},
+	}
+
+	for _, c := range cases {
+		i, params := SelectMuxPattern(patterns, c.method, c.host, c.path)
+		isEqual(t, i, c.index, c)
+		isEqual(t, params, c.params, c)
+	}
+
+	for i, p := range patterns {
+		for _, q := range patterns[i+1:] {
+			isEqual(t, p.ConflictsWith(q), false, p.String()+" "+q.String())
+		}
+	}
+
+	i, _ := SelectMuxPattern(patterns[1:], "GET", "", "/other")
+	isEqual(t, i, -1, "")
+}
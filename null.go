@@ -0,0 +1,89 @@
+package path
+
+import (
+	"database/sql/driver"
+)
+
+// NullPath is a Path that may be null. It is similar to sql.NullString, and
+// it allows a missing path to be distinguished from an empty one, both in
+// databases and in JSON. The zero value is null.
+type NullPath struct {
+	Path  Path
+	Valid bool // Valid is true if Path is not null
+}
+
+// NullPathOf returns a valid NullPath holding the path.
+func NullPathOf(path Path) NullPath {
+	return NullPath{Path: path, Valid: true}
+}
+
+// Scan parses some value. It implements sql.Scanner,
+// https://golang.org/pkg/database/sql/#Scanner
+//
+// SQL NULL gives a null path; other values are scanned by Path.Scan.
+func (np *NullPath) Scan(value interface{}) error {
+	if value == nil {
+		*np = NullPath{}
+		return nil
+	}
+	if err := np.Path.Scan(value); err != nil {
+		return err
+	}
+	np.Valid = true
+	return nil
+}
+
+// Value converts the value to a string, or to nil if the path is null.
+// It implements driver.Valuer,
+// https://golang.org/pkg/database/sql/driver/#Valuer
+func (np NullPath) Value() (driver.Value, error) {
+	if !np.Valid {
+		return nil, nil
+	}
+	return np.Path.Value()
+}
+
+// MarshalText implements encoding.TextMarshaler. A null path gives empty text.
+func (np NullPath) MarshalText() ([]byte, error) {
+	if !np.Valid {
+		return []byte{}, nil
+	}
+	return np.Path.MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Empty text gives a null
+// path; text cannot distinguish null from the empty path. Other text is
+// unmarshalled by Path.UnmarshalText.
+func (np *NullPath) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*np = NullPath{}
+		return nil
+	}
+	if err := np.Path.UnmarshalText(text); err != nil {
+		return err
+	}
+	np.Valid = true
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. A null path gives JSON null.
+func (np NullPath) MarshalJSON() ([]byte, error) {
+	if !np.Valid {
+		return []byte("null"), nil
+	}
+	return np.Path.MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler. JSON null gives a null path;
+// a JSON string is unmarshalled by Path.UnmarshalJSON.
+func (np *NullPath) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*np = NullPath{}
+		return nil
+	}
+	if err := np.Path.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	np.Valid = true
+	return nil
+}
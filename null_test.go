@@ -0,0 +1,110 @@
+package path
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestNullPathScan(t *testing.T) {
+	np := NullPathOf("/old")
+
+	err := np.Scan(nil)
+	isNil(t, err, "")
+	isEqual(t, np, NullPath{}, "")
+
+	err = np.Scan("")
+	isNil(t, err, "")
+	isEqual(t, np, NullPath{Path: "", Valid: true}, "")
+
+	err = np.Scan([]byte("/a/b"))
+	isNil(t, err, "")
+	isEqual(t, np, NullPath{Path: "/a/b", Valid: true}, "")
+
+	err = np.Scan(123)
+	isEqual(t, err != nil, true, "")
+}
+
+func TestNullPathValue(t *testing.T) {
+	v, err := NullPath{}.Value()
+	isNil(t, err, "")
+	isEqual(t, v, nil, "")
+
+	v, err = NullPathOf("").Value()
+	isNil(t, err, "")
+	isEqual(t, v, "", "")
+
+	v, err = NullPathOf("/a/b").Value()
+	isNil(t, err, "")
+	isEqual(t, v, "/a/b", "")
+}
+
+func TestNullPathJSON(t *testing.T) {
+	cases := []struct {
+		value NullPath
+		json  string
+	}{
+		{NullPath{}, `null`},
+		{NullPathOf(""), `""`},
+		{NullPathOf("/a/b"), `"/a/b"`},
+	}
+
+	for _, c := range cases {
+		data, err := json.Marshal(c.value)
+		isNil(t, err, c)
+		isEqual(t, string(data), c.json, c)
+
+		np := NullPathOf("/old")
+		err = json.Unmarshal(data, &np)
+		isNil(t, err, c)
+		isEqual(t, np, c.value, c)
+	}
+
+	type doc struct {
+		Path NullPath `json:"path"`
+	}
+	var d doc
+	err := json.Unmarshal([]byte(`{"path":null}`), &d)
+	isNil(t, err, "")
+	isEqual(t, d.Path.Valid, false, "")
+
+	err = json.Unmarshal([]byte(`{"path":1}`), &d)
+	isEqual(t, err != nil, true, "")
+}
+
+func TestNullPathText(t *testing.T) {
+	text, err := NullPath{}.MarshalText()
+	isNil(t, err, "")
+	isEqual(t, string(text), "", "")
+
+	text, err = NullPathOf("/a").MarshalText()
+	isNil(t, err, "")
+	isEqual(t, string(text), "/a", "")
+
+	var np NullPath
+	err = np.UnmarshalText([]byte("/a"))
+	isNil(t, err, "")
+	isEqual(t, np, NullPathOf("/a"), "")
+
+	err = np.UnmarshalText(nil)
+	isNil(t, err, "")
+	isEqual(t, np, NullPath{}, "")
+}
+
+func TestNullPathPolicy(t *testing.T) {
+	DefaultPolicy = Policy{Clean: true, Validator: NewValidator(RequireAbsolute())}
+	defer func() { DefaultPolicy = Policy{} }()
+
+	var np NullPath
+	err := json.Unmarshal([]byte(`"/a//b/"`), &np)
+	isNil(t, err, "")
+	isEqual(t, np, NullPathOf("/a/b"), "")
+
+	err = json.Unmarshal([]byte(`"a"`), &np)
+	isEqual(t, errors.Is(err, ErrInvalidPath), true, "")
+	isEqual(t, np, NullPathOf("/a/b"), "")
+
+	err = np.Scan(nil)
+	isNil(t, err, "")
+	isEqual(t, np, NullPath{}, "")
+}
@@ -0,0 +1,176 @@
+package path
+
+import (
+	"iter"
+	"strings"
+)
+
+// Parsed is a path whose slashes have been located once, so that its segments
+// can then be accessed repeatedly in constant time. This suits routers and
+// middleware that inspect the same path many times. Create one using
+// Path.Parse. The zero value is the empty path.
+type Parsed struct {
+	path Path
+	// start is the offset of the first segment, i.e. 1 after a leading slash.
+	start int
+	// slashes holds the offset of every slash after start, including any
+	// trailing slash.
+	slashes []int
+	// divisible is the largest n for which Divide(n) divides the path.
+	divisible int
+}
+
+// Parse locates the slashes in the path, returning a Parsed path. It makes a
+// single allocation, for the offsets of the slashes.
+func (path Path) Parse() Parsed {
+	p := Parsed{path: path}
+	if path == "" {
+		return p
+	}
+
+	s := string(path)
+	if s[0] == '/' {
+		p.start = 1
+	}
+
+	p.slashes = make([]int, 0, strings.Count(s[p.start:], "/"))
+	p.divisible = -1
+	for i := p.start; i < len(s); i++ {
+		if s[i] == '/' {
+			if p.divisible < 0 && i == p.segmentStart(len(p.slashes)) {
+				p.divisible = len(p.slashes) // an empty segment stops Divide
+			}
+			p.slashes = append(p.slashes, i)
+		}
+	}
+	if p.divisible < 0 {
+		p.divisible = len(p.slashes)
+	}
+	return p
+}
+
+// segmentStart returns the offset of the ith segment, counting every slash.
+func (p Parsed) segmentStart(i int) int {
+	if i == 0 {
+		return p.start
+	}
+	return p.slashes[i-1] + 1
+}
+
+// Path returns the path that was parsed. No copy is made.
+func (p Parsed) Path() Path {
+	return p.path
+}
+
+// String returns the path that was parsed.
+func (p Parsed) String() string {
+	return string(p.path)
+}
+
+// Len returns the number of segments in the path, as for Path.Len.
+func (p Parsed) Len() int {
+	if p.path == "" || p.path == "/" {
+		return 0
+	}
+	if p.hasTrailingSlash() {
+		return len(p.slashes)
+	}
+	return len(p.slashes) + 1
+}
+
+func (p Parsed) hasTrailingSlash() bool {
+	n := len(p.slashes)
+	return n > 0 && p.slashes[n-1] == len(p.path)-1
+}
+
+// Segment returns the ith segment, as for Path.Segment. A negative index counts
+// from the end. If the index is out of range, the result is "".
+func (p Parsed) Segment(i int) string {
+	n := p.Len()
+	if i < 0 {
+		i += n
+	}
+	if i < 0 || i >= n {
+		return ""
+	}
+	return p.segment(i)
+}
+
+func (p Parsed) segment(i int) string {
+	end := len(p.path)
+	if i < len(p.slashes) {
+		end = p.slashes[i]
+	}
+	return string(p.path[p.segmentStart(i):end])
+}
+
+// Segments returns the path split into the parts between slashes, as for
+// Path.Segments.
+func (p Parsed) Segments() []string {
+	n := p.Len()
+	if n == 0 {
+		return nil
+	}
+	segments := make([]string, n)
+	for i := range segments {
+		segments[i] = p.segment(i)
+	}
+	return segments
+}
+
+// All returns an iterator over the path segments, yielding the index and the
+// segment, as for Path.All.
+func (p Parsed) All() iter.Seq2[int, string] {
+	return func(yield func(int, string) bool) {
+		n := p.Len()
+		for i := 0; i < n; i++ {
+			if !yield(i, p.segment(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the path segments, as for Path.Values.
+func (p Parsed) Values() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		n := p.Len()
+		for i := 0; i < n; i++ {
+			if !yield(p.segment(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Divide divides a path at the nth slash, not counting the leading slash
+// if there is one. The result is exactly the same as for Path.Divide,
+// including when the path contains empty segments.
+//
+// The resulting pair (head, tail) always satisfy
+//
+//	head + tail = path
+func (p Parsed) Divide(nth int) (Path, Path) {
+	switch {
+	case p.path == "":
+		return "", ""
+	case nth <= 0:
+		return "", p.path
+	case nth > p.divisible:
+		return p.path, ""
+	}
+	pivot := p.slashes[nth-1]
+	return p.path[:pivot], p.path[pivot:]
+}
+
+// Drop is a helper for Divide that returns the tail part only.
+func (p Parsed) Drop(unwanted int) Path {
+	_, tail := p.Divide(unwanted)
+	return tail
+}
+
+// Take is a helper for Divide that returns the head part only.
+func (p Parsed) Take(wanted int) Path {
+	head, _ := p.Divide(wanted)
+	return head
+}
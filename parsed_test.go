@@ -0,0 +1,70 @@
+package path
+
+import (
+	"slices"
+	"testing"
+)
+
+var parsedCases = []Path{
+	"", "/", "//", "a", "/a", "a/", "/a/", "a/b/c", "/a/b/c", "/a/b/c/",
+	"a//b", "/a//b/", "//a", "a/b//", "/a/b/c/zz.png",
+}
+
+func TestParsedAgreesWithPath(t *testing.T) {
+	for _, path := range parsedCases {
+		p := path.Parse()
+		isEqual(t, p.Path(), path, path)
+		isEqual(t, p.String(), string(path), path)
+		isEqual(t, p.Len(), path.Len(), path)
+		isEqual(t, p.Segments(), path.Segments(), path)
+		isEqual(t, slices.Collect(p.Values()), slices.Collect(path.Values()), path)
+
+		for i := -p.Len() - 1; i <= p.Len(); i++ {
+			isEqual(t, p.Segment(i), path.Segment(i), path)
+		}
+
+		for n := 0; n <= p.Len()+2; n++ {
+			head, tail := p.Divide(n)
+			expHead, expTail := path.Divide(n)
+			isEqual(t, head, expHead, []interface{}{path, n})
+			isEqual(t, tail, expTail, []interface{}{path, n})
+			isEqual(t, p.Take(n), path.Take(n), []interface{}{path, n})
+			isEqual(t, p.Drop(n), path.Drop(n), []interface{}{path, n})
+		}
+	}
+}
+
+func TestParsedAll(t *testing.T) {
+	p := Path("/a/b/c/").Parse()
+	var indexes []int
+	var segs []string
+	for i, s := range p.All() {
+		indexes = append(indexes, i)
+		segs = append(segs, s)
+		if i == 1 {
+			break
+		}
+	}
+	isEqual(t, indexes, []int{0, 1}, "")
+	isEqual(t, segs, []string{"a", "b"}, "")
+}
+
+func TestParsedZeroValue(t *testing.T) {
+	var p Parsed
+	isEqual(t, p.Len(), 0, "")
+	isEqual(t, p.Segment(0), "", "")
+	isEqual(t, p.Path(), Path(""), "")
+	head, tail := p.Divide(1)
+	isEqual(t, head+tail, Path(""), "")
+}
+
+func BenchmarkParsedSegment(b *testing.B) {
+	p := Path("/a/b/c/d/e/f/g/zz.png").Parse()
+	b.ReportAllocs()
+	for b.Loop() {
+		for i := range p.Len() {
+			_ = p.Segment(i)
+		}
+		_, _ = p.Divide(4)
+	}
+}
@@ -1,6 +1,7 @@
 package path
 
 import (
+	"errors"
 	"strings"
 )
 
@@ -63,3 +64,137 @@ func SplitExt(path string) (string, string) {
 	}
 	return path, ""
 }
+
+// Rel returns a relative path that is lexically equivalent to targpath when
+// joined to basepath with an intervening separator. That is,
+// Join(basepath, Rel(basepath, targpath)) is equivalent to targpath itself.
+// On success, the returned path will always be relative to basepath,
+// even if basepath and targpath share no elements.
+// An error is returned if targpath can't be made relative to basepath,
+// either because one is absolute and the other is relative, or because
+// knowing the current working directory would be necessary to compute it.
+// Rel calls Clean on the result.
+//
+// Rel behaves like filepath.Rel, except that it always uses forward slashes.
+func Rel(basepath, targpath string) (string, error) {
+	base := Clean(basepath)
+	targ := Clean(targpath)
+	if targ == base {
+		return ".", nil
+	}
+	if base == "." {
+		base = ""
+	}
+	if targ == "." {
+		targ = ""
+	}
+
+	if IsAbs(base) != IsAbs(targ) {
+		return "", errors.New("Rel: can't make " + targpath + " relative to " + basepath)
+	}
+
+	// Position base[b0:bi] and targ[t0:ti] at the first differing elements.
+	bl := len(base)
+	tl := len(targ)
+	var b0, bi, t0, ti int
+	for {
+		for bi < bl && base[bi] != '/' {
+			bi++
+		}
+		for ti < tl && targ[ti] != '/' {
+			ti++
+		}
+		if targ[t0:ti] != base[b0:bi] {
+			break
+		}
+		if bi < bl {
+			bi++
+		}
+		if ti < tl {
+			ti++
+		}
+		b0 = bi
+		t0 = ti
+	}
+
+	if base[b0:bi] == ".." {
+		return "", errors.New("Rel: can't make " + targpath + " relative to " + basepath)
+	}
+
+	if b0 == bl {
+		return targ[t0:], nil
+	}
+
+	// Base elements left. Must go up before going down.
+	ups := strings.Repeat("../", strings.Count(base[b0:bl], "/")) + ".."
+	if t0 == tl {
+		return ups, nil
+	}
+	return ups + "/" + targ[t0:], nil
+}
+
+// Resolve resolves a relative reference against a base path, as for URL paths.
+// It implements the path part of the algorithm in RFC 3986 section 5.2:
+//
+//   - an empty ref gives the base path unchanged;
+//   - an absolute ref has its dot segments removed;
+//   - otherwise, ref is merged with all of base up to and including its last
+//     slash, then dot segments are removed.
+//
+// So, unlike Join, the last segment of base is replaced unless base ends with a
+// slash, and a trailing slash or dot segment in ref gives a trailing slash in
+// the result. Unlike Clean, multiple slashes are retained and ".." segments
+// that would go above the root are discarded.
+//
+// For a URL that has an authority (host) but an empty path, use "/" as the base.
+func Resolve(base, ref string) string {
+	switch {
+	case ref == "":
+		return base
+	case ref[0] == '/':
+		return removeDotSegments(ref)
+	}
+
+	if i := strings.LastIndexByte(base, '/'); i >= 0 {
+		ref = base[:i+1] + ref
+	}
+	return removeDotSegments(ref)
+}
+
+// removeDotSegments implements RFC 3986 section 5.2.4.
+func removeDotSegments(in string) string {
+	out := make([]byte, 0, len(in))
+
+	for len(in) > 0 {
+		switch {
+		case strings.HasPrefix(in, "../"):
+			in = in[3:]
+		case strings.HasPrefix(in, "./"), strings.HasPrefix(in, "/./"):
+			in = in[2:]
+		case in == "/.":
+			in = "/"
+		case strings.HasPrefix(in, "/../"):
+			in = in[3:]
+			out = removeLastSegment(out)
+		case in == "/..":
+			in = "/"
+			out = removeLastSegment(out)
+		case in == "." || in == "..":
+			in = ""
+		default:
+			end := strings.IndexByte(in[1:], '/') + 1
+			if end == 0 {
+				end = len(in)
+			}
+			out = append(out, in[:end]...)
+			in = in[end:]
+		}
+	}
+
+	return string(out)
+}
+
+func removeLastSegment(out []byte) []byte {
+	i := max(0, strings.LastIndexByte(string(out), '/'))
+	return out[:i]
+}
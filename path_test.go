@@ -47,6 +47,139 @@ func TestDivideAndDropLeading(t *testing.T) {
 	}
 }
 
+func TestRel(t *testing.T) {
+	cases := []struct {
+		base, target, expected string
+	}{
+		{"/a/b", "/a/b", "."},
+		{"/a/b", "/a/b/c/d", "c/d"},
+		{"/a/b/", "/a/b/c/", "c"},
+		{"/a/b/c/d", "/a/b", "../.."},
+		{"/a/b/c", "/a/x/y", "../../x/y"},
+		{"/a/bc", "/a/b", "../b"},
+		{"/", "/a/b", "a/b"},
+		{"/a/b", "/", "../.."},
+		{"a/b", "a/c", "../c"},
+		{".", "a/b", "a/b"},
+		{"", "a/b", "a/b"},
+		{"a/b", ".", "../.."},
+		{"a", "../b", "../../b"},
+		{"../a", "../a/b", "b"},
+		{"/a/./b/../c", "/a/c/d", "d"},
+	}
+
+	for _, c := range cases {
+		rel, err := Rel(c.base, c.target)
+		isNil(t, err, c)
+		isEqual(t, rel, c.expected, c)
+	}
+}
+
+func TestRelErrors(t *testing.T) {
+	cases := []struct {
+		base, target string
+	}{
+		{"/a", "b"},
+		{"a", "/b"},
+		{"../a", "b"},
+		{"..", "a"},
+	}
+
+	for _, c := range cases {
+		_, err := Rel(c.base, c.target)
+		isEqual(t, err.Error(), "Rel: can't make "+c.target+" relative to "+c.base, c)
+	}
+}
+
+func TestRelInvariant(t *testing.T) {
+	paths := []Path{"", "/", "/a", "/a/b", "/a/b/", "/a/bc", "/a/b/c/d", "/x/y", "/a/../b",
+		".", "a", "a/b", "a/b/c", "b/c", "../a", "../../a/b", "..", "a/./b//c"}
+
+	for _, base := range paths {
+		for _, target := range paths {
+			rel, err := base.Rel(target)
+			if err != nil {
+				continue
+			}
+			isEqual(t, rel.IsAbs(), false, base+" -> "+target)
+			isEqual(t, Of(string(base), string(rel)), target.Clean(), base+" -> "+target)
+			isEqual(t, Join(string(base), string(rel)), string(target.Clean()), base+" -> "+target)
+			if base != "" {
+				isEqual(t, base.Join(rel).Clean(), target.Clean(), base+" -> "+target)
+			}
+		}
+	}
+}
+
+// The examples are from RFC 3986 section 5.4, using the path of the base URI
+// "http://a/b/c/d;p?q". References with a scheme, authority, query or fragment
+// are omitted because they do not apply to paths alone.
+func TestResolveRFC3986(t *testing.T) {
+	const base = "/b/c/d;p"
+
+	normal := []struct {
+		ref, expected string
+	}{
+		{"g", "/b/c/g"},
+		{"./g", "/b/c/g"},
+		{"g/", "/b/c/g/"},
+		{"/g", "/g"},
+		{";x", "/b/c/;x"},
+		{"g;x", "/b/c/g;x"},
+		{"", "/b/c/d;p"},
+		{".", "/b/c/"},
+		{"./", "/b/c/"},
+		{"..", "/b/"},
+		{"../", "/b/"},
+		{"../g", "/b/g"},
+		{"../..", "/"},
+		{"../../", "/"},
+		{"../../g", "/g"},
+	}
+
+	abnormal := []struct {
+		ref, expected string
+	}{
+		{"../../../g", "/g"},
+		{"../../../../g", "/g"},
+		{"/./g", "/g"},
+		{"/../g", "/g"},
+		{"g.", "/b/c/g."},
+		{".g", "/b/c/.g"},
+		{"g..", "/b/c/g.."},
+		{"..g", "/b/c/..g"},
+		{"./../g", "/b/g"},
+		{"./g/.", "/b/c/g/"},
+		{"g/./h", "/b/c/g/h"},
+		{"g/../h", "/b/c/h"},
+		{"g;x=1/./y", "/b/c/g;x=1/y"},
+		{"g;x=1/../y", "/b/c/y"},
+	}
+
+	for _, c := range append(normal, abnormal...) {
+		isEqual(t, Resolve(base, c.ref), c.expected, c.ref)
+		isEqual(t, Path(base).Resolve(Path(c.ref)), Path(c.expected), c.ref)
+	}
+}
+
+func TestResolveOtherBases(t *testing.T) {
+	cases := []struct {
+		base, ref, expected string
+	}{
+		{"/a/b/", "c", "/a/b/c"},
+		{"/a/b", "c", "/a/c"},
+		{"/", "c/./d/../e", "/c/e"},
+		{"", "c", "c"},
+		{"a/b", "c", "a/c"},
+		{"a", "../c", "c"},
+		{"/a//b", "c", "/a//c"},
+	}
+
+	for _, c := range cases {
+		isEqual(t, Resolve(c.base, c.ref), c.expected, c)
+	}
+}
+
 //-------------------------------------------------------------------------------------------------
 
 func isNil(t *testing.T, a, hint interface{}) {
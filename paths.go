@@ -0,0 +1,331 @@
+package path
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Paths is a list of paths that is stored in a database as an array, such as
+// a PostgreSQL text[] column. It cannot hold NULL elements; use NullPaths
+// for arrays that may contain them.
+type Paths []Path
+
+// Scan parses a PostgreSQL array literal such as {/a,"/b c"}. It implements
+// sql.Scanner, https://golang.org/pkg/database/sql/#Scanner
+//
+// Quoted elements and backslash escapes are supported. Each element is
+// normalised and checked using DefaultPolicy. NULL elements are rejected,
+// because saving the paths again could not restore them. SQL NULL gives a
+// nil slice. Multi-dimensional arrays are not supported. Any error is an
+// *InvalidPathError.
+func (paths *Paths) Scan(value interface{}) error {
+	elements, err := scanArray("Paths.Scan", value)
+	if err != nil {
+		return err
+	}
+	if elements == nil {
+		*paths = nil
+		return nil
+	}
+
+	list := make(Paths, len(elements))
+	for i, e := range elements {
+		if !e.Valid {
+			return &InvalidPathError{Op: "Paths.Scan", Value: value, Err: errNullElement}
+		}
+		list[i] = e.Path
+	}
+	*paths = list
+	return nil
+}
+
+var errNullElement = errors.New("NULL element; use NullPaths instead")
+
+// Value converts the paths to a PostgreSQL array literal, quoting elements
+// where necessary. A nil slice gives SQL NULL. It implements driver.Valuer,
+// https://golang.org/pkg/database/sql/driver/#Valuer
+func (paths Paths) Value() (driver.Value, error) {
+	if paths == nil {
+		return nil, nil
+	}
+	return paths.String(), nil
+}
+
+// String returns the paths as a PostgreSQL array literal.
+func (paths Paths) String() string {
+	b := &strings.Builder{}
+	b.WriteByte('{')
+	for i, p := range paths {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		writeArrayElement(b, string(p))
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// NullPaths is a list of paths that may contain nulls, stored in a database as
+// an array, such as a PostgreSQL text[] column. NULL elements are kept, so
+// they are distinct from empty paths and survive being scanned and saved.
+type NullPaths []NullPath
+
+// Scan parses a PostgreSQL array literal such as {/a,NULL,""}. It implements
+// sql.Scanner, https://golang.org/pkg/database/sql/#Scanner
+//
+// It is the same as Paths.Scan except that NULL elements give null paths.
+func (paths *NullPaths) Scan(value interface{}) error {
+	elements, err := scanArray("NullPaths.Scan", value)
+	if err != nil {
+		return err
+	}
+	*paths = elements
+	return nil
+}
+
+// Value converts the paths to a PostgreSQL array literal, quoting elements
+// where necessary and writing NULL for null paths. A nil slice gives SQL NULL.
+// It implements driver.Valuer, https://golang.org/pkg/database/sql/driver/#Valuer
+func (paths NullPaths) Value() (driver.Value, error) {
+	if paths == nil {
+		return nil, nil
+	}
+	return paths.String(), nil
+}
+
+// String returns the paths as a PostgreSQL array literal.
+func (paths NullPaths) String() string {
+	b := &strings.Builder{}
+	b.WriteByte('{')
+	for i, p := range paths {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		if p.Valid {
+			writeArrayElement(b, string(p.Path))
+		} else {
+			b.WriteString("NULL")
+		}
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+//-------------------------------------------------------------------------------------------------
+
+func writeArrayElement(b *strings.Builder, s string) {
+	if s != "" && !strings.EqualFold(s, "NULL") && !strings.ContainsAny(s, "{},\"\\ \t\n\r\v\f") {
+		b.WriteString(s)
+		return
+	}
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+	b.WriteByte('"')
+}
+
+// scanArray parses a PostgreSQL array literal for the operation op. SQL NULL
+// gives a nil slice.
+func scanArray(op string, value interface{}) ([]NullPath, error) {
+	var s string
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return nil, &InvalidPathError{Op: op, Value: value, Err: fmt.Errorf("unsupported type %T", value)}
+	}
+
+	list, err := parseArrayElements(op, s)
+	if err != nil {
+		if _, ok := err.(*InvalidPathError); !ok {
+			err = &InvalidPathError{Op: op, Value: s, Err: err}
+		}
+	}
+	return list, err
+}
+
+func parseArrayElements(op, s string) ([]NullPath, error) {
+	s = strings.TrimSpace(s)
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return nil, fmt.Errorf("array must be enclosed in braces")
+	}
+
+	body := s[1 : len(s)-1]
+	if strings.TrimSpace(body) == "" {
+		return []NullPath{}, nil
+	}
+
+	var list []NullPath
+	i := 0
+	for {
+		for i < len(body) && isArraySpace(body[i]) {
+			i++
+		}
+
+		b := &strings.Builder{}
+		quoted := i < len(body) && body[i] == '"'
+		if quoted {
+			i++
+			for {
+				if i == len(body) {
+					return nil, fmt.Errorf("unterminated quoted element")
+				}
+				c := body[i]
+				i++
+				if c == '"' {
+					break
+				}
+				if c == '\\' {
+					if i == len(body) {
+						return nil, fmt.Errorf("unterminated quoted element")
+					}
+					c = body[i]
+					i++
+				}
+				b.WriteByte(c)
+			}
+			for i < len(body) && isArraySpace(body[i]) {
+				i++
+			}
+		} else {
+			for i < len(body) && body[i] != ',' {
+				c := body[i]
+				i++
+				switch c {
+				case '{', '}', '"':
+					return nil, fmt.Errorf("unexpected %q at offset %d", c, i)
+				case '\\':
+					if i == len(body) {
+						return nil, fmt.Errorf("trailing backslash")
+					}
+					c = body[i]
+					i++
+				}
+				b.WriteByte(c)
+			}
+		}
+
+		elem := b.String()
+		null := false
+		if !quoted {
+			elem = strings.TrimRight(elem, " \t\n\r\v\f")
+			if elem == "" {
+				return nil, fmt.Errorf("empty element")
+			}
+			null = strings.EqualFold(elem, "NULL")
+		}
+
+		if null {
+			list = append(list, NullPath{})
+		} else {
+			p, err := applyDefaultPolicy(op, elem)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, NullPathOf(p))
+		}
+
+		if i == len(body) {
+			return list, nil
+		}
+		if body[i] != ',' {
+			return nil, fmt.Errorf("expected ',' at offset %d", i+1)
+		}
+		i++
+	}
+}
+
+func isArraySpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// PathList is a list of paths that is stored as a single colon-separated
+// string, in the style of the PATH environment variable.
+type PathList []Path
+
+// ParsePathList splits a colon-separated list. An empty string gives an empty
+// list; otherwise, empty elements are kept as empty paths.
+func ParsePathList(s string) PathList {
+	if s == "" {
+		return PathList{}
+	}
+	parts := strings.Split(s, ":")
+	list := make(PathList, len(parts))
+	for i, p := range parts {
+		list[i] = Path(p)
+	}
+	return list
+}
+
+// Scan parses a colon-separated list. It implements sql.Scanner,
+// https://golang.org/pkg/database/sql/#Scanner
+//
+// SQL NULL gives a nil slice. Each path is normalised and checked using
+// DefaultPolicy. Any error is an *InvalidPathError.
+func (list *PathList) Scan(value interface{}) error {
+	var s string
+	switch v := value.(type) {
+	case nil:
+		*list = nil
+		return nil
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return &InvalidPathError{Op: "PathList.Scan", Value: value, Err: fmt.Errorf("unsupported type %T", value)}
+	}
+
+	parsed := ParsePathList(s)
+	for i, p := range parsed {
+		normalised, err := applyDefaultPolicy("PathList.Scan", string(p))
+		if err != nil {
+			return err
+		}
+		parsed[i] = normalised
+	}
+	*list = parsed
+	return nil
+}
+
+// Value converts the list to a colon-separated string. A nil slice gives SQL
+// NULL. It returns an error if any path contains a colon, because the list
+// could not be parsed back again. It implements driver.Valuer,
+// https://golang.org/pkg/database/sql/driver/#Valuer
+func (list PathList) Value() (driver.Value, error) {
+	if list == nil {
+		return nil, nil
+	}
+	for _, p := range list {
+		if strings.IndexByte(string(p), ':') >= 0 {
+			return nil, fmt.Errorf("PathList.Value: %q contains a colon", string(p))
+		}
+	}
+	return list.String(), nil
+}
+
+// String returns the paths as a colon-separated list.
+func (list PathList) String() string {
+	b := &strings.Builder{}
+	for i, p := range list {
+		if i > 0 {
+			b.WriteByte(':')
+		}
+		b.WriteString(string(p))
+	}
+	return b.String()
+}
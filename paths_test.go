@@ -0,0 +1,176 @@
+package path
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPathsScan(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected Paths
+	}{
+		{`{}`, Paths{}},
+		{` { } `, Paths{}},
+		{`{/a,/b/c}`, Paths{"/a", "/b/c"}},
+		{`{ /a , /b }`, Paths{"/a", "/b"}},
+		{`{"/a b","/c,d","x\"y","p\\q"}`, Paths{"/a b", "/c,d", `x"y`, `p\q`}},
+		{`{"NULL","null",""}`, Paths{"NULL", "null", ""}},
+		{`{a\,b}`, Paths{"a,b"}},
+	}
+
+	for _, c := range cases {
+		var actual Paths
+		err := actual.Scan(c.input)
+		isNil(t, err, c.input)
+		isEqual(t, actual, c.expected, c.input)
+
+		err = actual.Scan([]byte(c.input))
+		isNil(t, err, c.input)
+		isEqual(t, actual, c.expected, c.input)
+	}
+
+	p := Paths{"x"}
+	err := p.Scan(nil)
+	isNil(t, err, "")
+	isEqual(t, p, Paths(nil), "")
+}
+
+func TestPathsScanErrors(t *testing.T) {
+	cases := []string{
+		``,
+		`/a,/b`,
+		`{/a`,
+		`{"/a}`,
+		`{/a,}`,
+		`{,/a}`,
+		`{{/a},{/b}}`,
+		`{"/a"x}`,
+		`{a\}`,
+	}
+
+	for _, c := range cases {
+		var p Paths
+		err := p.Scan(c)
+		isEqual(t, err != nil, true, c)
+	}
+
+	var p Paths
+	err := p.Scan(123)
+	isEqual(t, err.Error(), "Paths.Scan(123): unsupported type int", "")
+	isEqual(t, errors.Is(err, ErrInvalidPath), true, "")
+
+	err = p.Scan("{/a,}")
+	isEqual(t, err.Error(), `Paths.Scan("{/a,}"): empty element`, "")
+	isEqual(t, errors.Is(err, ErrInvalidPath), true, "")
+
+	p = Paths{"/x"}
+	err = p.Scan("{/a,NULL}")
+	isEqual(t, err.Error(), `Paths.Scan("{/a,NULL}"): NULL element; use NullPaths instead`, "")
+	isEqual(t, errors.Is(err, ErrInvalidPath), true, "")
+	isEqual(t, p, Paths{"/x"}, "")
+}
+
+func TestPathsValue(t *testing.T) {
+	cases := []struct {
+		input    Paths
+		expected string
+	}{
+		{Paths{}, `{}`},
+		{Paths{"/a", "/b/c"}, `{/a,/b/c}`},
+		{Paths{"/a b", "/c,d", `x"y`, `p\q`, "{}"}, `{"/a b","/c,d","x\"y","p\\q","{}"}`},
+		{Paths{"", "NULL", "null"}, `{"","NULL","null"}`},
+	}
+
+	for _, c := range cases {
+		v, err := c.input.Value()
+		isNil(t, err, c.input)
+		isEqual(t, v, c.expected, c.input)
+
+		var back Paths
+		err = back.Scan(v)
+		isNil(t, err, c.input)
+		isEqual(t, back, c.input, c.input)
+	}
+
+	v, err := Paths(nil).Value()
+	isNil(t, err, "")
+	isEqual(t, v, nil, "")
+}
+
+func TestNullPathsRoundTrip(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected NullPaths
+	}{
+		{`{}`, NullPaths{}},
+		{`{NULL}`, NullPaths{{}}},
+		{`{/a,NULL,"",null,"NULL","/b c"}`, NullPaths{NullPathOf("/a"), {}, NullPathOf(""), {}, NullPathOf("NULL"), NullPathOf("/b c")}},
+	}
+
+	for _, c := range cases {
+		var actual NullPaths
+		err := actual.Scan(c.input)
+		isNil(t, err, c.input)
+		isEqual(t, actual, c.expected, c.input)
+
+		v, err := actual.Value()
+		isNil(t, err, c.input)
+
+		var back NullPaths
+		err = back.Scan(v)
+		isNil(t, err, c.input)
+		isEqual(t, back, c.expected, c.input)
+	}
+
+	v, err := NullPaths{NullPathOf("/a"), {}, NullPathOf("")}.Value()
+	isNil(t, err, "")
+	isEqual(t, v, `{/a,NULL,""}`, "")
+
+	np := NullPaths{{}}
+	err = np.Scan(nil)
+	isNil(t, err, "")
+	isEqual(t, np, NullPaths(nil), "")
+
+	v, err = NullPaths(nil).Value()
+	isNil(t, err, "")
+	isEqual(t, v, nil, "")
+
+	err = np.Scan(123)
+	isEqual(t, err.Error(), "NullPaths.Scan(123): unsupported type int", "")
+	err = np.Scan("{/a")
+	isEqual(t, errors.Is(err, ErrInvalidPath), true, "")
+}
+
+func TestPathList(t *testing.T) {
+	var list PathList
+	err := list.Scan("/usr/bin:/bin::/opt/x")
+	isNil(t, err, "")
+	isEqual(t, list, PathList{"/usr/bin", "/bin", "", "/opt/x"}, "")
+
+	v, err := list.Value()
+	isNil(t, err, "")
+	isEqual(t, v, "/usr/bin:/bin::/opt/x", "")
+
+	err = list.Scan([]byte(""))
+	isNil(t, err, "")
+	isEqual(t, list, PathList{}, "")
+
+	v, err = list.Value()
+	isNil(t, err, "")
+	isEqual(t, v, "", "")
+
+	err = list.Scan(nil)
+	isNil(t, err, "")
+	isEqual(t, list, PathList(nil), "")
+
+	v, err = list.Value()
+	isNil(t, err, "")
+	isEqual(t, v, nil, "")
+
+	_, err = PathList{"/a", "c:/b"}.Value()
+	isEqual(t, err.Error(), `PathList.Value: "c:/b" contains a colon`, "")
+
+	err = list.Scan(1.5)
+	isEqual(t, err.Error(), "PathList.Scan(1.5): unsupported type float64", "")
+}
@@ -0,0 +1,217 @@
+package path
+
+import (
+	"fmt"
+	std "path"
+	"strings"
+)
+
+// Pattern is a compiled shell file name pattern. Compiling a pattern once and
+// reusing it is much cheaper than calling MatchDeep repeatedly with the same
+// pattern. A Pattern is safe for concurrent use.
+//
+// The pattern syntax is as for MatchDeep, including brace expansion.
+type Pattern struct {
+	source string
+	prefix string
+	alts   [][]patternSegment // one for each brace expansion
+}
+
+type segmentKind uint8
+
+const (
+	literalSegment segmentKind = iota // matched by string equality
+	globSegment                       // matched by std.Match
+	deepSegment                       // '**', matches zero or more segments
+)
+
+type patternSegment struct {
+	text string
+	kind segmentKind
+}
+
+func (seg patternSegment) match(name string) bool {
+	if seg.kind == literalSegment {
+		return seg.text == name
+	}
+	ok, _ := std.Match(seg.text, name)
+	return ok
+}
+
+// Compile parses a shell file name pattern. If successful, it returns a Pattern
+// that can be used to match against paths. If the pattern is malformed, the
+// error wraps ErrBadPattern.
+func Compile(pattern string) (*Pattern, error) {
+	p, err := compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", err, pattern)
+	}
+	return p, nil
+}
+
+// MustCompile is like Compile but panics if the pattern is malformed.
+func MustCompile(pattern string) *Pattern {
+	p, err := Compile(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func compile(pattern string) (*Pattern, error) {
+	expanded, err := Expand(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return compileExpanded(pattern, expanded)
+}
+
+// compileExpanded builds a pattern from its brace expansions, which must not
+// themselves contain brace terms.
+func compileExpanded(pattern string, expanded []string) (*Pattern, error) {
+	p := &Pattern{source: pattern}
+	for i, e := range expanded {
+		segments, err := compileSegments(e)
+		if err != nil {
+			return nil, err
+		}
+		p.alts = append(p.alts, segments)
+
+		prefix := literalPrefix(segments)
+		if i == 0 {
+			p.prefix = prefix
+		} else {
+			p.prefix = commonPrefix(p.prefix, prefix)
+		}
+	}
+	return p, nil
+}
+
+func compileSegments(pattern string) ([]patternSegment, error) {
+	var segments []patternSegment
+	for seg := range strings.SplitSeq(pattern, "/") {
+		switch {
+		case seg == "**":
+			if n := len(segments); n > 0 && segments[n-1].kind == deepSegment {
+				continue // consecutive '**' are the same as one
+			}
+			segments = append(segments, patternSegment{kind: deepSegment})
+
+		case strings.ContainsAny(seg, `*?[\`):
+			if _, err := std.Match(seg, ""); err != nil {
+				return nil, err
+			}
+			segments = append(segments, patternSegment{text: seg, kind: globSegment})
+
+		default:
+			segments = append(segments, patternSegment{text: seg, kind: literalSegment})
+		}
+	}
+	return segments, nil
+}
+
+func commonPrefix(a, b string) string {
+	n := min(len(a), len(b))
+	for i := range n {
+		if a[i] != b[i] {
+			return a[:i]
+		}
+	}
+	return a[:n]
+}
+
+// literalPrefix finds the fixed leading part of the pattern that every
+// matching name must start with.
+func literalPrefix(segments []patternSegment) string {
+	b := &strings.Builder{}
+	for i, seg := range segments {
+		switch seg.kind {
+		case deepSegment:
+			return b.String()
+		case globSegment:
+			if i > 0 {
+				b.WriteByte('/')
+			}
+			b.WriteString(seg.text[:strings.IndexAny(seg.text, `*?[\`)])
+			return b.String()
+		}
+		if i > 0 {
+			b.WriteByte('/')
+		}
+		b.WriteString(seg.text)
+	}
+	return b.String()
+}
+
+// Match reports whether the path matches the pattern.
+func (p *Pattern) Match(path Path) bool {
+	name := string(path)
+	if !strings.HasPrefix(name, p.prefix) {
+		return false
+	}
+	for _, segments := range p.alts {
+		if matchSegments(segments, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Prefix returns the literal leading part of the pattern, before any wildcard
+// terms. Every path that matches the pattern starts with this prefix.
+func (p *Pattern) Prefix() string {
+	return p.prefix
+}
+
+// String returns the source text used to compile the pattern.
+func (p *Pattern) String() string {
+	return p.source
+}
+
+// matchSegments matches compiled pattern segments against a name, segment by
+// segment. A '**' is treated like '*' in a classic wildcard match, with the
+// name's segments as the characters: only the most recent '**' is ever
+// revisited, so the cost is proportional to the product of the numbers of
+// pattern and name segments, however many '**' there are.
+func matchSegments(pattern []patternSegment, name string) bool {
+	const used = -1 // the offset once every name segment has been consumed
+
+	// next returns the segment starting at offset i and the offset of the one after it.
+	next := func(i int) (string, int) {
+		slash := strings.IndexByte(name[i:], '/')
+		if slash < 0 {
+			return name[i:], used
+		}
+		return name[i : i+slash], i + slash + 1
+	}
+
+	pi, ni := 0, 0
+	deepPi, deepNi := -1, 0 // where to resume after the most recent '**'
+	for ni != used {
+		if pi < len(pattern) && pattern[pi].kind == deepSegment {
+			pi++
+			deepPi, deepNi = pi, ni
+			continue
+		}
+
+		seg, after := next(ni)
+		if pi < len(pattern) && pattern[pi].match(seg) {
+			pi++
+			ni = after
+			continue
+		}
+
+		if deepPi < 0 {
+			return false
+		}
+		// let the most recent '**' consume one more segment
+		_, deepNi = next(deepNi)
+		pi, ni = deepPi, deepNi
+	}
+
+	// the name is used up, so only a trailing '**' can remain
+	for pi < len(pattern) && pattern[pi].kind == deepSegment {
+		pi++
+	}
+	return pi == len(pattern)
+}
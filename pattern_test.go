@@ -0,0 +1,85 @@
+package path
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCompile(t *testing.T) {
+	cases := []struct {
+		pattern, prefix string
+		match, noMatch  []Path
+	}{
+		{"/a/b/zz.png", "/a/b/zz.png", []Path{"/a/b/zz.png"}, []Path{"/a/b/zz.png/", "/a/b", "a/b/zz.png"}},
+		{"/a/b/*.png", "/a/b/", []Path{"/a/b/zz.png", "/a/b/.png"}, []Path{"/a/b/c/zz.png", "/a/c/zz.png"}},
+		{"/a/b?/*.png", "/a/b", []Path{"/a/bc/zz.png"}, []Path{"/a/b/zz.png"}},
+		{"/assets/**/*.png", "/assets", []Path{"/assets/x.png", "/assets/a/b/x.png"}, []Path{"/assets", "/assetsx/x.png"}},
+		{"/assets/**", "/assets", []Path{"/assets", "/assets/", "/assets/a/b"}, []Path{"/asset", "/assetsx"}},
+		{"/a/**/**/b", "/a", []Path{"/a/b", "/a/x/y/b"}, []Path{"/a/x/y"}},
+		{"/a/**/x/**/y", "/a", []Path{"/a/x/y", "/a/x/x/y", "/a/b/x/c/d/y", "/a/x/y/x/y"}, []Path{"/a/y/x", "/a/x/y/z"}},
+		{"**/b/**/b", "", []Path{"b/b", "a/b/b/b", "/b/x/b"}, []Path{"b", "/b/x/b/c"}},
+		{"**/*.go", "", []Path{"x.go", "/a/x.go", "a/b/x.go"}, []Path{"x.goo"}},
+		{`/a/\*`, "/a/", []Path{"/a/*"}, []Path{"/a/b"}},
+		{"", "", []Path{""}, []Path{"/"}},
+	}
+
+	for _, c := range cases {
+		p, err := Compile(c.pattern)
+		isNil(t, err, c.pattern)
+		isEqual(t, p.String(), c.pattern, c.pattern)
+		isEqual(t, p.Prefix(), c.prefix, c.pattern)
+
+		for _, name := range c.match {
+			isEqual(t, p.Match(name), true, name)
+			m, _ := MatchDeep(c.pattern, string(name))
+			isEqual(t, m, true, name)
+		}
+		for _, name := range c.noMatch {
+			isEqual(t, p.Match(name), false, name)
+			m, _ := MatchDeep(c.pattern, string(name))
+			isEqual(t, m, false, name)
+		}
+	}
+}
+
+func TestCompileBadPattern(t *testing.T) {
+	p, err := Compile("/a/[b")
+	isEqual(t, p, (*Pattern)(nil), "")
+	isEqual(t, errors.Is(err, ErrBadPattern), true, "")
+	isEqual(t, err.Error(), `syntax error in pattern: "/a/[b"`, "")
+}
+
+func TestMustCompile(t *testing.T) {
+	isEqual(t, MustCompile("/a/*").Match("/a/b"), true, "")
+
+	defer func() {
+		isEqual(t, recover() != nil, true, "")
+	}()
+	MustCompile("/a/[b")
+}
+
+func TestPatternMatchManyDeepSegments(t *testing.T) {
+	p := MustCompile("/a/**/x/**/x/**/x/**/*.png")
+	long := Path("/a/" + strings.Repeat("x/", 150))
+	isEqual(t, p.Match(long+"z.jpg"), false, "")
+	isEqual(t, p.Match(long+"z.png"), true, "")
+}
+
+func BenchmarkPatternMatch(b *testing.B) {
+	p := MustCompile("/assets/**/*.png")
+	b.ReportAllocs()
+	for b.Loop() {
+		p.Match("/assets/a/b/c/x.png")
+		p.Match("/other/a/b/c/x.png")
+	}
+}
+
+func BenchmarkPatternMatchLongNonMatching(b *testing.B) {
+	p := MustCompile("/a/**/x/**/x/**/x/**/*.png")
+	name := Path("/a/" + strings.Repeat("x/", 150) + "z.jpg")
+	b.ReportAllocs()
+	for b.Loop() {
+		p.Match(name)
+	}
+}
@@ -0,0 +1,197 @@
+package path
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// PatternSet holds many shell file name patterns and finds which of them match
+// a given path in a single pass. The patterns are stored in a trie of path
+// segments, so the cost of matching depends mostly on the length of the path
+// rather than on the number of patterns.
+//
+// The pattern syntax is as for MatchDeep, including brace expansion. Patterns
+// are identified by their index, i.e. the order in which they were added.
+//
+// A PatternSet is safe for concurrent matching, but Add must not be called
+// concurrently with any other method.
+type PatternSet struct {
+	patterns []*Pattern
+	root     trieNode
+}
+
+type trieNode struct {
+	literals map[string]*trieNode
+	globs    []globEdge
+	deep     *trieNode // the '**' edge
+	loop     bool      // set on the target of a '**' edge, which consumes any segment
+	ends     []int     // indexes of the patterns that end here
+}
+
+type globEdge struct {
+	seg  patternSegment
+	node *trieNode
+}
+
+// NewPatternSet compiles the patterns and builds a new PatternSet holding them.
+// If any pattern is malformed, the error wraps ErrBadPattern.
+func NewPatternSet(patterns ...string) (*PatternSet, error) {
+	set := &PatternSet{}
+	for _, pattern := range patterns {
+		if _, err := set.Add(pattern); err != nil {
+			return nil, err
+		}
+	}
+	return set, nil
+}
+
+// Add compiles a pattern and adds it to the set, returning its index.
+// If the pattern is malformed, the error wraps ErrBadPattern.
+func (set *PatternSet) Add(pattern string) (int, error) {
+	p, err := compile(pattern)
+	if err != nil {
+		return -1, fmt.Errorf("%w: %q", err, pattern)
+	}
+
+	index := len(set.patterns)
+	set.patterns = append(set.patterns, p)
+
+	for _, segments := range p.alts {
+		node := &set.root
+		for _, seg := range segments {
+			node = node.child(seg)
+		}
+		node.ends = append(node.ends, index)
+	}
+	return index, nil
+}
+
+func (n *trieNode) child(seg patternSegment) *trieNode {
+	switch seg.kind {
+	case deepSegment:
+		if n.deep == nil {
+			n.deep = &trieNode{loop: true}
+		}
+		return n.deep
+
+	case globSegment:
+		for _, g := range n.globs {
+			if g.seg.text == seg.text {
+				return g.node
+			}
+		}
+		c := &trieNode{}
+		n.globs = append(n.globs, globEdge{seg: seg, node: c})
+		return c
+	}
+
+	if n.literals == nil {
+		n.literals = make(map[string]*trieNode)
+	}
+	c := n.literals[seg.text]
+	if c == nil {
+		c = &trieNode{}
+		n.literals[seg.text] = c
+	}
+	return c
+}
+
+// Len returns the number of patterns in the set.
+func (set *PatternSet) Len() int {
+	return len(set.patterns)
+}
+
+// Pattern returns the ith pattern in the set.
+func (set *PatternSet) Pattern(i int) *Pattern {
+	return set.patterns[i]
+}
+
+// Matches returns the indexes of all the patterns that match the path, in
+// ascending order. It returns nil if there are none.
+func (set *PatternSet) Matches(path Path) []int {
+	var indexes []int
+	set.root.walk(string(path), func(ends []int) bool {
+		indexes = append(indexes, ends...)
+		return true
+	})
+
+	// a pattern can end at more than one node when it has brace alternatives
+	slices.Sort(indexes)
+	return slices.Compact(indexes)
+}
+
+// First returns the lowest index of the patterns that match the path.
+// If none match, it returns -1 and false.
+func (set *PatternSet) First(path Path) (int, bool) {
+	first := -1
+	set.root.walk(string(path), func(ends []int) bool {
+		for _, i := range ends {
+			if first < 0 || i < first {
+				first = i
+			}
+		}
+		return first != 0
+	})
+	return first, first >= 0
+}
+
+// Any reports whether any of the patterns match the path.
+func (set *PatternSet) Any(path Path) bool {
+	found := false
+	set.root.walk(string(path), func(ends []int) bool {
+		found = true
+		return false
+	})
+	return found
+}
+
+// walk visits every node that terminates a match for the name, passing their
+// pattern indexes to yield, until yield returns false. It runs the trie as a
+// set of states, one segment at a time, so each node is visited at most once
+// per segment and the cost is proportional to the length of the name.
+func (n *trieNode) walk(name string, yield func([]int) bool) {
+	current := addState(nil, n)
+	var next []*trieNode
+
+	for len(current) > 0 {
+		head, tail, more := strings.Cut(name, "/")
+
+		next = next[:0]
+		for _, s := range current {
+			if s.loop {
+				next = addState(next, s)
+			}
+			if c := s.literals[head]; c != nil {
+				next = addState(next, c)
+			}
+			for _, g := range s.globs {
+				if g.seg.match(head) {
+					next = addState(next, g.node)
+				}
+			}
+		}
+		current, next = next, current
+
+		if !more {
+			break
+		}
+		name = tail
+	}
+
+	for _, s := range current {
+		if len(s.ends) > 0 && !yield(s.ends) {
+			return
+		}
+	}
+}
+
+// addState adds a node to a set of states, along with the node reached by its
+// '**' edge, which can consume zero segments.
+func addState(states []*trieNode, n *trieNode) []*trieNode {
+	for n != nil && !slices.Contains(states, n) {
+		states = append(states, n)
+		n = n.deep
+	}
+	return states
+}
@@ -0,0 +1,105 @@
+package path
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestPatternSet(t *testing.T) {
+	set, err := NewPatternSet(
+		"/api/**",          // 0
+		"/api/users/*",     // 1
+		"/api/users/admin", // 2
+		"**/*.png",         // 3
+		"/assets/**/*.png", // 4
+		"/api/*/admin",     // 5
+		"/api/users/*",     // 6 (a duplicate)
+	)
+	isNil(t, err, "")
+	isEqual(t, set.Len(), 7, "")
+	isEqual(t, set.Pattern(4).String(), "/assets/**/*.png", "")
+
+	cases := []struct {
+		path     Path
+		expected []int
+	}{
+		{"/api", []int{0}},
+		{"/api/users/admin", []int{0, 1, 2, 5, 6}},
+		{"/api/users/bob", []int{0, 1, 6}},
+		{"/api/groups/admin", []int{0, 5}},
+		{"/api/users/x.png", []int{0, 1, 3, 6}},
+		{"/assets/x.png", []int{3, 4}},
+		{"/assets/a/b/x.png", []int{3, 4}},
+		{"x.png", []int{3}},
+		{"/other/x.jpg", nil},
+		{"", nil},
+	}
+
+	for _, c := range cases {
+		isEqual(t, set.Matches(c.path), c.expected, c.path)
+
+		first, ok := set.First(c.path)
+		isEqual(t, ok, c.expected != nil, c.path)
+		if ok {
+			isEqual(t, first, c.expected[0], c.path)
+		} else {
+			isEqual(t, first, -1, c.path)
+		}
+
+		isEqual(t, set.Any(c.path), c.expected != nil, c.path)
+	}
+}
+
+func TestPatternSetAgreesWithPattern(t *testing.T) {
+	patterns := []string{"/a/**/b/**", "/a/*/b", "**", "/a/**/c", "/[ab]/b/c", "a/b", "/a/b/"}
+	names := []Path{"/a/b", "/a/x/b", "/a/b/c", "/a/x/b/y/c", "/b/b/c", "a/b", "/a/b/", "", "/"}
+
+	set, err := NewPatternSet(patterns...)
+	isNil(t, err, "")
+
+	for _, name := range names {
+		var expected []int
+		for i, pattern := range patterns {
+			if MustCompile(pattern).Match(name) {
+				expected = append(expected, i)
+			}
+		}
+		isEqual(t, set.Matches(name), expected, name)
+	}
+}
+
+func TestPatternSetManyDeepSegments(t *testing.T) {
+	set, err := NewPatternSet("/a/**/x/**/x/**/x/**/*.png", "/a/**/{x,y}/**/*.png")
+	isNil(t, err, "")
+	long := Path("/a/" + strings.Repeat("x/", 150))
+	isEqual(t, set.Any(long+"z.jpg"), false, "")
+	isEqual(t, set.Matches(long+"z.png"), []int{0, 1}, "")
+}
+
+func TestPatternSetBadPattern(t *testing.T) {
+	_, err := NewPatternSet("/a/*", "/a/[b")
+	isEqual(t, errors.Is(err, ErrBadPattern), true, "")
+}
+
+func BenchmarkPatternSetMatches(b *testing.B) {
+	set := &PatternSet{}
+	for i := range 500 {
+		set.Add(fmt.Sprintf("/api/v%d/users/*", i))
+	}
+	set.Add("/assets/**/*.png")
+	b.ReportAllocs()
+	for b.Loop() {
+		set.Matches("/api/v250/users/bob")
+	}
+}
+
+func BenchmarkPatternSetLongNonMatching(b *testing.B) {
+	set, _ := NewPatternSet("/a/**/x/**/x/**/x/**/*.png", "**/y/**/*.png")
+	name := Path("/a/" + strings.Repeat("x/", 150) + "z.jpg")
+	b.ReportAllocs()
+	for b.Loop() {
+		set.Any(name)
+	}
+}
@@ -0,0 +1,95 @@
+package path
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidPath indicates that a value could not be read as a path, either
+// because it has the wrong type or because it was rejected by a Policy.
+// Use errors.Is to test for it.
+var ErrInvalidPath = errors.New("invalid path")
+
+// InvalidPathError is returned when a value cannot be read as a path.
+type InvalidPathError struct {
+	Op    string      // the operation, such as "Path.Scan"
+	Value interface{} // the offending value
+	Err   error       // the reason
+}
+
+func (e *InvalidPathError) Error() string {
+	return fmt.Sprintf("%s(%#v): %v", e.Op, e.Value, e.Err)
+}
+
+// Unwrap returns the reason, which may be a *ValidationError.
+func (e *InvalidPathError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrInvalidPath.
+func (e *InvalidPathError) Is(target error) bool {
+	return target == ErrInvalidPath
+}
+
+// Policy controls how incoming paths are normalised and checked. The zero
+// value accepts every path as it is.
+type Policy struct {
+	// Clean causes each path to be Cleaned.
+	Clean bool
+
+	// LeadingSlash causes a leading slash to be added to each relative path.
+	LeadingSlash bool
+
+	// TrimTrailingSlash causes any trailing slash to be removed, except from
+	// the root path "/".
+	TrimTrailingSlash bool
+
+	// Validator, if not nil, checks each path after it has been normalised.
+	// Invalid paths are rejected.
+	Validator *Validator
+}
+
+// DefaultPolicy is applied by Scan, UnmarshalText, UnmarshalJSON and
+// UnmarshalBinary for Path, and so also for NullPath, Paths and PathList. It
+// accepts every path as it is unless it is altered, typically during program
+// initialisation.
+var DefaultPolicy Policy
+
+// Apply normalises the path and then validates it. Any leading slash is added
+// before the path is Cleaned, so the result is clean. The empty path is left
+// empty, although the Validator still checks it. If the path is invalid,
+// the error is a *ValidationError.
+func (p Policy) Apply(path Path) (Path, error) {
+	if path != "" {
+		if p.LeadingSlash && !path.IsAbs() {
+			path = "/" + path
+		}
+		if p.Clean {
+			path = path.Clean()
+		}
+		if p.TrimTrailingSlash && path != "/" {
+			path = Path(strings.TrimRight(string(path), "/"))
+			if path == "" {
+				path = "/"
+			}
+		}
+	}
+
+	if p.Validator != nil {
+		if err := p.Validator.Validate(path); err != nil {
+			return "", err
+		}
+	}
+	return path, nil
+}
+
+// applyDefaultPolicy applies DefaultPolicy to s, reporting any problem as an
+// *InvalidPathError for the operation op.
+func applyDefaultPolicy(op, s string) (Path, error) {
+	p, err := DefaultPolicy.Apply(Path(s))
+	if err != nil {
+		return "", &InvalidPathError{Op: op, Value: s, Err: err}
+	}
+	return p, nil
+}
@@ -0,0 +1,102 @@
+package path
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestPolicyApply(t *testing.T) {
+	clean := Policy{Clean: true}
+	slashes := Policy{LeadingSlash: true, TrimTrailingSlash: true}
+	all := Policy{Clean: true, LeadingSlash: true, TrimTrailingSlash: true}
+
+	cases := []struct {
+		policy   Policy
+		input    Path
+		expected Path
+	}{
+		{Policy{}, "a//b/../c/", "a//b/../c/"},
+		{clean, "a//b/../c/", "a/c"},
+		{clean, "/", "/"},
+		{clean, "", ""},
+		{slashes, "a//b/", "/a//b"},
+		{slashes, "/a/b//", "/a/b"},
+		{slashes, "/", "/"},
+		{slashes, "//", "/"},
+		{slashes, "", ""},
+		{all, "a/../../b/", "/b"},
+		{all, ".", "/"},
+		{all, "../x", "/x"},
+	}
+
+	for _, c := range cases {
+		actual, err := c.policy.Apply(c.input)
+		isNil(t, err, c)
+		isEqual(t, actual, c.expected, c)
+	}
+}
+
+func TestPolicyApplyRejects(t *testing.T) {
+	p := Policy{Clean: true, Validator: NewValidator(ForbidDotSegments())}
+
+	_, err := p.Apply("a/../../b")
+	var ve *ValidationError
+	isEqual(t, errors.As(err, &ve), true, "")
+	isEqual(t, ve.Path, Path("../b"), "")
+}
+
+func TestDefaultPolicy(t *testing.T) {
+	DefaultPolicy = Policy{
+		Clean:     true,
+		Validator: NewValidator(RequireAbsolute(), ForbidReservedNames()),
+	}
+	defer func() { DefaultPolicy = Policy{} }()
+
+	var p Path
+	err := p.Scan("/a//b/../c/")
+	isNil(t, err, "")
+	isEqual(t, p, Path("/a/c"), "")
+
+	err = json.Unmarshal([]byte(`"/x/./y"`), &p)
+	isNil(t, err, "")
+	isEqual(t, p, Path("/x/y"), "")
+
+	err = p.UnmarshalText([]byte("x/../../CON"))
+	isEqual(t, errors.Is(err, ErrInvalidPath), true, "")
+	var ve *ValidationError
+	isEqual(t, errors.As(err, &ve), true, "")
+	isEqual(t, err.Error(), `Path.UnmarshalText("x/../../CON"): invalid path "../CON": is not absolute; segment 1 "CON" is a reserved name`, "")
+	isEqual(t, p, Path("/x/y"), "")
+
+	err = p.Scan([]byte("rel"))
+	isEqual(t, err.Error(), `Path.Scan("rel"): invalid path "rel": is not absolute`, "")
+
+	err = json.Unmarshal([]byte(`"rel"`), &p)
+	isEqual(t, err.Error(), `Path.UnmarshalJSON("rel"): invalid path "rel": is not absolute`, "")
+	isEqual(t, p, Path("/x/y"), "")
+
+	err = p.UnmarshalBinary(nil)
+	isEqual(t, errors.Is(err, ErrInvalidPath), true, "")
+
+	var paths Paths
+	err = paths.Scan(`{/a//b,/c/}`)
+	isNil(t, err, "")
+	isEqual(t, paths, Paths{"/a/b", "/c"}, "")
+
+	var nullPaths NullPaths
+	err = nullPaths.Scan(`{/a//b,NULL}`)
+	isNil(t, err, "")
+	isEqual(t, nullPaths, NullPaths{NullPathOf("/a/b"), {}}, "")
+
+	err = paths.Scan(`{/a,b}`)
+	isEqual(t, err.Error(), `Paths.Scan("b"): invalid path "b": is not absolute`, "")
+
+	var list PathList
+	err = list.Scan("/a/:/b/./c")
+	isNil(t, err, "")
+	isEqual(t, list, PathList{"/a", "/b/c"}, "")
+
+	err = list.Scan("/a:b")
+	isEqual(t, errors.Is(err, ErrInvalidPath), true, "")
+}
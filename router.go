@@ -0,0 +1,386 @@
+package path
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Router stores values of any type under route templates and finds the value
+// for a path. The templates have the same syntax as for ParseTemplate, so they
+// may contain literal segments, parameters with optional constraints and
+// a trailing catch-all. The routes are held in a radix tree.
+//
+// Lookup never blocks and is safe to use concurrently with Insert and Delete.
+// Each update builds a modified copy of the affected part of the tree, then
+// replaces the whole tree atomically, so a lookup always sees either the old
+// routes or the new ones. Updates are serialised with respect to each other.
+//
+// When more than one route could match a path, literal segments are preferred
+// over parameters, and parameters over catch-alls. Constrained parameters are
+// tried before unconstrained ones, otherwise in the order they were inserted.
+//
+// The zero value is an empty Router ready to use.
+type Router[T any] struct {
+	mu    sync.Mutex // serialises updates
+	table atomic.Pointer[routeTable[T]]
+}
+
+type routeTable[T any] struct {
+	root *routeNode[T]
+	size int
+}
+
+type routeNode[T any] struct {
+	prefix     string          // literal text matched on entry to this node
+	constraint string          // source of re, for a parameter or catch-all node
+	re         *regexp.Regexp  // optional constraint, for a parameter or catch-all node
+	children   []*routeNode[T] // literal children, each with a distinct first byte
+	params     []*routeNode[T] // parameter children, each with a distinct constraint
+	catchAlls  []*routeNode[T] // catch-all children, each with a distinct constraint
+	route      *route[T]       // the route that ends here, if any
+}
+
+type route[T any] struct {
+	names []string
+	value T
+}
+
+type routeTokenKind uint8
+
+const (
+	literalToken routeTokenKind = iota
+	paramToken
+	catchAllToken
+)
+
+type routeToken struct {
+	kind       routeTokenKind
+	text       string // the literal
+	constraint string
+	re         *regexp.Regexp
+}
+
+// routeTokens converts a template into a sequence of literal texts and parameters.
+func routeTokens(t *Template) []routeToken {
+	var tokens []routeToken
+	b := &strings.Builder{}
+
+	for i, seg := range t.segments {
+		if i > 0 {
+			b.WriteByte('/')
+		}
+		if !seg.param {
+			b.WriteString(seg.text)
+			continue
+		}
+
+		if b.Len() > 0 {
+			tokens = append(tokens, routeToken{kind: literalToken, text: b.String()})
+			b.Reset()
+		}
+
+		kind := paramToken
+		if seg.catchAll {
+			kind = catchAllToken
+		}
+		tokens = append(tokens, routeToken{kind: kind, constraint: seg.source, re: seg.re})
+	}
+
+	if b.Len() > 0 {
+		tokens = append(tokens, routeToken{kind: literalToken, text: b.String()})
+	}
+	return tokens
+}
+
+// Insert adds a route, replacing any existing route with the same template.
+// Templates that differ only in their parameter names are the same route. If
+// the template is malformed, the error is a *TemplateError.
+func (r *Router[T]) Insert(template string, value T) error {
+	t, err := ParseTemplate(template)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	old := r.load()
+	rt := &route[T]{names: t.Names(), value: value}
+	root, added := insertRoute(old.root, routeTokens(t), rt)
+
+	table := &routeTable[T]{root: root, size: old.size}
+	if added {
+		table.size++
+	}
+	r.table.Store(table)
+	return nil
+}
+
+// Delete removes the route with the given template, reporting whether it was
+// present. If the template is malformed, the error is a *TemplateError.
+func (r *Router[T]) Delete(template string) (bool, error) {
+	t, err := ParseTemplate(template)
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	old := r.load()
+	root, found := deleteRoute(old.root, routeTokens(t))
+	if found {
+		if root == nil {
+			root = &routeNode[T]{}
+		}
+		r.table.Store(&routeTable[T]{root: root, size: old.size - 1})
+	}
+	return found, nil
+}
+
+// Len returns the number of routes.
+func (r *Router[T]) Len() int {
+	return r.load().size
+}
+
+// Lookup finds the route that matches the path, returning its value and
+// parameters. If no route matches, ok is false.
+func (r *Router[T]) Lookup(path Path) (value T, params Params, ok bool) {
+	var buf [8]string
+	rt, values := r.load().root.lookup(string(path), buf[:0])
+	if rt == nil {
+		return value, nil, false
+	}
+
+	if len(rt.names) > 0 {
+		params = make(Params, len(rt.names))
+		for i, name := range rt.names {
+			params[i] = Param{Name: name, Value: values[i]}
+		}
+	}
+	return rt.value, params, true
+}
+
+func (r *Router[T]) load() *routeTable[T] {
+	if t := r.table.Load(); t != nil {
+		return t
+	}
+	return &routeTable[T]{root: &routeNode[T]{}}
+}
+
+//-------------------------------------------------------------------------------------------------
+
+func (n *routeNode[T]) clone() *routeNode[T] {
+	c := *n
+	c.children = slices.Clone(n.children)
+	c.params = slices.Clone(n.params)
+	c.catchAlls = slices.Clone(n.catchAlls)
+	return &c
+}
+
+func (n *routeNode[T]) isEmpty() bool {
+	return n.route == nil && len(n.children) == 0 && len(n.params) == 0 && len(n.catchAlls) == 0
+}
+
+// insertRoute returns a copy of n with the route added after the tokens. It
+// reports whether the route is new rather than a replacement.
+func insertRoute[T any](n *routeNode[T], tokens []routeToken, rt *route[T]) (*routeNode[T], bool) {
+	c := n.clone()
+	if len(tokens) == 0 {
+		c.route = rt
+		return c, n.route == nil
+	}
+
+	tok := tokens[0]
+	var added bool
+	switch tok.kind {
+	case literalToken:
+		added = c.insertLiteral(tok.text, tokens[1:], rt)
+
+	case paramToken:
+		c.params, added = insertWild(c.params, tok, tokens[1:], rt)
+
+	case catchAllToken:
+		c.catchAlls, added = insertWild(c.catchAlls, tok, tokens[1:], rt)
+	}
+	return c, added
+}
+
+// insertWild adds the route below the parameter or catch-all node for the token,
+// which is added if needed.
+func insertWild[T any](nodes []*routeNode[T], tok routeToken, tokens []routeToken, rt *route[T]) ([]*routeNode[T], bool) {
+	for i, w := range nodes {
+		if w.constraint == tok.constraint {
+			var added bool
+			nodes[i], added = insertRoute(w, tokens, rt)
+			return nodes, added
+		}
+	}
+
+	w, _ := insertRoute(&routeNode[T]{constraint: tok.constraint, re: tok.re}, tokens, rt)
+	if n := len(nodes); n > 0 && nodes[n-1].constraint == "" {
+		// keep the unconstrained node last, so it is tried last
+		return slices.Insert(nodes, n-1, w), true
+	}
+	return append(nodes, w), true
+}
+
+// insertLiteral adds the route below the literal text, splitting an existing
+// child if they share only part of their prefixes. The receiver must be a
+// private copy.
+func (n *routeNode[T]) insertLiteral(text string, tokens []routeToken, rt *route[T]) bool {
+	for i, child := range n.children {
+		if child.prefix[0] != text[0] {
+			continue
+		}
+
+		common := len(commonPrefix(child.prefix, text))
+		var added bool
+		switch {
+		case common == len(child.prefix) && common == len(text):
+			n.children[i], added = insertRoute(child, tokens, rt)
+
+		case common == len(child.prefix):
+			c := child.clone()
+			added = c.insertLiteral(text[common:], tokens, rt)
+			n.children[i] = c
+
+		default:
+			tail := child.clone()
+			tail.prefix = child.prefix[common:]
+			mid := &routeNode[T]{prefix: child.prefix[:common], children: []*routeNode[T]{tail}}
+			if common == len(text) {
+				mid, added = insertRoute(mid, tokens, rt)
+			} else {
+				added = mid.insertLiteral(text[common:], tokens, rt)
+			}
+			n.children[i] = mid
+		}
+		return added
+	}
+
+	leaf, _ := insertRoute(&routeNode[T]{prefix: text}, tokens, rt)
+	n.children = append(n.children, leaf)
+	return true
+}
+
+// deleteRoute returns a copy of n without the route after the tokens, or nil
+// if the copy would be empty. It reports whether the route was found.
+func deleteRoute[T any](n *routeNode[T], tokens []routeToken) (*routeNode[T], bool) {
+	if len(tokens) == 0 {
+		if n.route == nil {
+			return n, false
+		}
+		c := n.clone()
+		c.route = nil
+		return c.orNil(), true
+	}
+
+	tok := tokens[0]
+	switch tok.kind {
+	case literalToken:
+		for i, child := range n.children {
+			if !strings.HasPrefix(tok.text, child.prefix) {
+				continue
+			}
+			rest := tokens[1:]
+			if len(child.prefix) < len(tok.text) {
+				rest = append([]routeToken{{kind: literalToken, text: tok.text[len(child.prefix):]}}, rest...)
+			}
+			d, found := deleteRoute(child, rest)
+			if !found {
+				return n, false
+			}
+			c := n.clone()
+			c.children = replaceOrRemove(c.children, i, d)
+			return c.orNil(), true
+		}
+
+	case paramToken, catchAllToken:
+		nodes := n.params
+		if tok.kind == catchAllToken {
+			nodes = n.catchAlls
+		}
+		for i, w := range nodes {
+			if w.constraint != tok.constraint {
+				continue
+			}
+			d, found := deleteRoute(w, tokens[1:])
+			if !found {
+				return n, false
+			}
+			c := n.clone()
+			if tok.kind == catchAllToken {
+				c.catchAlls = replaceOrRemove(c.catchAlls, i, d)
+			} else {
+				c.params = replaceOrRemove(c.params, i, d)
+			}
+			return c.orNil(), true
+		}
+	}
+
+	return n, false
+}
+
+func (n *routeNode[T]) orNil() *routeNode[T] {
+	if n.isEmpty() {
+		return nil
+	}
+	return n
+}
+
+func replaceOrRemove[T any](nodes []*routeNode[T], i int, n *routeNode[T]) []*routeNode[T] {
+	if n == nil {
+		return slices.Delete(nodes, i, i+1)
+	}
+	nodes[i] = n
+	return nodes
+}
+
+// lookup finds the route for the remaining path, after the prefix of n has
+// been matched. It appends the captured parameter values, unescaped as for
+// Template.Match, to values.
+func (n *routeNode[T]) lookup(path string, values []string) (*route[T], []string) {
+	if path == "" && n.route != nil {
+		return n.route, values
+	}
+
+	if path != "" {
+		for _, child := range n.children {
+			if child.prefix[0] == path[0] {
+				if strings.HasPrefix(path, child.prefix) {
+					if rt, vs := child.lookup(path[len(child.prefix):], values); rt != nil {
+						return rt, vs
+					}
+				}
+				break
+			}
+		}
+
+		seg, _, _ := strings.Cut(path, "/")
+		if seg != "" {
+			value := unescapeMux(seg)
+			for _, p := range n.params {
+				if p.re == nil || p.re.MatchString(value) {
+					if rt, vs := p.lookup(path[len(seg):], append(values, value)); rt != nil {
+						return rt, vs
+					}
+				}
+			}
+		}
+	}
+
+	if len(n.catchAlls) > 0 {
+		value := unescapeMux(path)
+		for _, c := range n.catchAlls {
+			if c.re == nil || c.re.MatchString(value) {
+				return c.route, append(values, value)
+			}
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,158 @@
+package path
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestRouterLookup(t *testing.T) {
+	r := &Router[string]{}
+	for _, tpl := range []string{
+		"/",
+		"/users",
+		"/users/",
+		"/users/new",
+		"/users/{id:[0-9]+}",
+		"/users/{name}",
+		"/users/{id}/posts/{slug...}",
+		"/usersettings",
+		"/files/{rest...}",
+		"/files/{rest...:.*[.]png}",
+		"/{page}",
+	} {
+		isNil(t, r.Insert(tpl, tpl), tpl)
+	}
+	isEqual(t, r.Len(), 11, "")
+
+	cases := []struct {
+		path     Path
+		expected string
+		params   Params
+	}{
+		{"/", "/", nil},
+		{"/users", "/users", nil},
+		{"/users/", "/users/", nil},
+		{"/users/new", "/users/new", nil},
+		{"/users/newer", "/users/{name}", Params{{"name", "newer"}}},
+		{"/users/42", "/users/{id:[0-9]+}", Params{{"id", "42"}}},
+		{"/users/bob", "/users/{name}", Params{{"name", "bob"}}},
+		{"/users/7/posts/2024/hello", "/users/{id}/posts/{slug...}", Params{{"id", "7"}, {"slug", "2024/hello"}}},
+		{"/users/7/posts/", "/users/{id}/posts/{slug...}", Params{{"id", "7"}, {"slug", ""}}},
+		{"/usersettings", "/usersettings", nil},
+		{"/userset", "/{page}", Params{{"page", "userset"}}},
+		{"/files/a/b.txt", "/files/{rest...}", Params{{"rest", "a/b.txt"}}},
+		{"/files/a/b.png", "/files/{rest...:.*[.]png}", Params{{"rest", "a/b.png"}}},
+		{"/files/", "/files/{rest...}", Params{{"rest", ""}}},
+		{"/about", "/{page}", Params{{"page", "about"}}},
+		{"/users/%34%32", "/users/{id:[0-9]+}", Params{{"id", "42"}}},
+		{"/users/a%2Fb", "/users/{name}", Params{{"name", "a/b"}}},
+		{"/files/a%2Fb/c%20d.png", "/files/{rest...:.*[.]png}", Params{{"rest", "a/b/c d.png"}}},
+	}
+
+	for _, c := range cases {
+		v, params, ok := r.Lookup(c.path)
+		isEqual(t, ok, true, c.path)
+		isEqual(t, v, c.expected, c.path)
+		isEqual(t, params, c.params, c.path)
+	}
+
+	for _, p := range []Path{"", "/users/7/posts", "/about/us", "//"} {
+		_, _, ok := r.Lookup(p)
+		isEqual(t, ok, false, p)
+	}
+}
+
+func TestRouterConstrainedCatchAll(t *testing.T) {
+	r := &Router[int]{}
+	isNil(t, r.Insert("/files/{rest...:.*[.]png}", 1), "")
+
+	v, params, ok := r.Lookup("/files/a/b.png")
+	isEqual(t, ok, true, "")
+	isEqual(t, v, 1, "")
+	isEqual(t, params, Params{{"rest", "a/b.png"}}, "")
+
+	_, _, ok = r.Lookup("/files/a/b.jpg")
+	isEqual(t, ok, false, "")
+}
+
+func TestRouterReplaceAndDelete(t *testing.T) {
+	r := &Router[int]{}
+	isNil(t, r.Insert("/a/{x}", 1), "")
+	isNil(t, r.Insert("/a/{y}", 2), "")
+	isNil(t, r.Insert("/ab", 3), "")
+	isEqual(t, r.Len(), 2, "")
+
+	v, params, _ := r.Lookup("/a/q")
+	isEqual(t, v, 2, "")
+	isEqual(t, params, Params{{"y", "q"}}, "")
+
+	found, err := r.Delete("/a/{z}")
+	isNil(t, err, "")
+	isEqual(t, found, true, "")
+	isEqual(t, r.Len(), 1, "")
+
+	_, _, ok := r.Lookup("/a/q")
+	isEqual(t, ok, false, "")
+	v, _, ok = r.Lookup("/ab")
+	isEqual(t, ok, true, "")
+	isEqual(t, v, 3, "")
+
+	found, _ = r.Delete("/a")
+	isEqual(t, found, false, "")
+	found, _ = r.Delete("/abc")
+	isEqual(t, found, false, "")
+	found, _ = r.Delete("/ab")
+	isEqual(t, found, true, "")
+	isEqual(t, r.Len(), 0, "")
+
+	_, _, ok = r.Lookup("/ab")
+	isEqual(t, ok, false, "")
+}
+
+func TestRouterSnapshotIsUnchangedByUpdates(t *testing.T) {
+	r := &Router[int]{}
+	isNil(t, r.Insert("/abc", 1), "")
+	before := r.table.Load()
+
+	isNil(t, r.Insert("/abd", 2), "")
+	isNil(t, r.Insert("/abc", 3), "")
+
+	rt, _ := before.root.lookup("/abc", nil)
+	isEqual(t, rt.value, 1, "")
+	rt, _ = before.root.lookup("/abd", nil)
+	isEqual(t, rt, (*route[int])(nil), "")
+}
+
+func TestRouterBadTemplate(t *testing.T) {
+	r := &Router[int]{}
+	err := r.Insert("/a/{b", 1)
+	var te *TemplateError
+	isEqual(t, errors.As(err, &te), true, "")
+
+	_, err = r.Delete("/a/{b")
+	isEqual(t, errors.As(err, &te), true, "")
+}
+
+func TestRouterConcurrentUpdates(t *testing.T) {
+	r := &Router[int]{}
+	wg := sync.WaitGroup{}
+	for i := range 8 {
+		wg.Go(func() {
+			for j := range 50 {
+				tpl := fmt.Sprintf("/g%d/r%d/{id}", i, j)
+				if err := r.Insert(tpl, j); err != nil {
+					t.Error(err)
+				}
+				r.Lookup(Path(fmt.Sprintf("/g%d/r%d/x", i, j/2)))
+			}
+		})
+	}
+	wg.Wait()
+
+	isEqual(t, r.Len(), 400, "")
+	v, _, ok := r.Lookup("/g3/r17/x")
+	isEqual(t, ok, true, "")
+	isEqual(t, v, 17, "")
+}
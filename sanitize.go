@@ -0,0 +1,137 @@
+package path
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Sanitizer makes path segments safe to use as file names on common platforms,
+// including Windows. It deals with
+//
+//   - slashes and backslashes;
+//   - NUL and other control characters;
+//   - the characters forbidden by Windows: < > : " | ? *
+//   - invalid UTF-8;
+//   - trailing dots and spaces, which Windows strips;
+//   - device names reserved by Windows, such as CON, NUL and COM1;
+//   - names that are too long.
+type Sanitizer struct {
+	// Replacement is substituted for each unsafe character. If it is empty,
+	// unsafe characters are removed instead. It is also prefixed to reserved
+	// names, or "_" is used if it is empty. Any unsafe characters in
+	// Replacement itself are replaced by "_".
+	Replacement string
+
+	// MaxBytes limits the length of each segment, in bytes. Longer segments are
+	// truncated without splitting any UTF-8 character. Zero means no limit.
+	MaxBytes int
+}
+
+// DefaultSanitizer is used by SanitizeSegment and Path.Sanitize. It replaces
+// unsafe characters with an underscore and limits segments to 255 bytes,
+// which is the limit for most file systems.
+var DefaultSanitizer = Sanitizer{Replacement: "_", MaxBytes: 255}
+
+// SanitizeSegment makes a name safe to use as a single path segment, using
+// DefaultSanitizer. The result may be empty, e.g. for "..", in which case the
+// caller should choose some other name.
+func SanitizeSegment(name string) string {
+	return DefaultSanitizer.Segment(name)
+}
+
+// Sanitize makes each segment of the path safe, using DefaultSanitizer.
+// See Sanitizer.Path.
+func (path Path) Sanitize() Path {
+	return DefaultSanitizer.Path(path)
+}
+
+// Segment makes a name safe to use as a single path segment. The result may be
+// empty, e.g. for "..", in which case the caller should choose some other name.
+func (s Sanitizer) Segment(name string) string {
+	replacement := s.replacement()
+	b := &strings.Builder{}
+	for i, r := range name {
+		if r == utf8.RuneError {
+			if _, size := utf8.DecodeRuneInString(name[i:]); size == 1 {
+				b.WriteString(replacement) // invalid UTF-8
+				continue
+			}
+		}
+		if isUnsafe(r) {
+			b.WriteString(replacement)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+
+	safe := s.truncate(trimDotsAndSpaces(b.String()))
+
+	// the reserved names are checked after truncation, which could produce
+	// one, e.g. "CONSOLE" truncated to "CON"
+	if isReservedName(safe) {
+		prefix := replacement
+		if prefix == "" {
+			prefix = "_"
+		}
+		safe = s.truncate(prefix + safe)
+	}
+
+	return safe
+}
+
+// replacement returns Replacement with any unsafe characters, including
+// invalid UTF-8, replaced by "_".
+func (s Sanitizer) replacement() string {
+	return strings.Map(func(r rune) rune {
+		if r == utf8.RuneError || isUnsafe(r) {
+			return '_'
+		}
+		return r
+	}, s.Replacement)
+}
+
+// truncate shortens a name to at most MaxBytes without splitting any UTF-8
+// character, then trims any trailing dots and spaces this exposes.
+func (s Sanitizer) truncate(name string) string {
+	if s.MaxBytes <= 0 || len(name) <= s.MaxBytes {
+		return name
+	}
+	end := s.MaxBytes
+	for end > 0 && !utf8.RuneStart(name[end]) {
+		end--
+	}
+	return trimDotsAndSpaces(name[:end])
+}
+
+// Path makes each segment of the path safe using Segment. Segments that become
+// empty are dropped. A leading slash is kept but a trailing slash is not.
+func (s Sanitizer) Path(path Path) Path {
+	b := &strings.Builder{}
+	if path.IsAbs() {
+		b.WriteByte('/')
+	}
+
+	first := true
+	for seg := range path.Values() {
+		if safe := s.Segment(seg); safe != "" {
+			if !first {
+				b.WriteByte('/')
+			}
+			b.WriteString(safe)
+			first = false
+		}
+	}
+	return Path(b.String())
+}
+
+func isUnsafe(r rune) bool {
+	switch {
+	case r < 0x20, r == 0x7f, 0x80 <= r && r < 0xa0:
+		return true // control characters
+	}
+	return strings.ContainsRune(`/\<>:"|?*`, r)
+}
+
+func trimDotsAndSpaces(s string) string {
+	return strings.TrimRight(s, ". ")
+}
@@ -0,0 +1,72 @@
+package path
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSanitizeSegment(t *testing.T) {
+	cases := []struct {
+		input, expected string
+	}{
+		{"report.pdf", "report.pdf"},
+		{"a/b\\c", "a_b_c"},
+		{"what?<now>:*|\"", "what__now_____"},
+		{"nul\x00byte\x1f\x7f", "nul_byte__"},
+		{"bad\xffutf8", "bad_utf8"},
+		{"trailing. . ", "trailing"},
+		{"..", ""},
+		{".", ""},
+		{".hidden", ".hidden"},
+		{"CON", "_CON"},
+		{"con.txt", "_con.txt"},
+		{"Com1", "_Com1"},
+		{"COM10", "COM10"},
+		{"console", "console"},
+		{"naïve café", "naïve café"},
+	}
+
+	for _, c := range cases {
+		isEqual(t, SanitizeSegment(c.input), c.expected, c.input)
+	}
+}
+
+func TestSanitizerOptions(t *testing.T) {
+	strip := Sanitizer{}
+	isEqual(t, strip.Segment("a/b:c"), "abc", "")
+	isEqual(t, strip.Segment("aux"), "_aux", "")
+
+	dash := Sanitizer{Replacement: "-", MaxBytes: 8}
+	isEqual(t, dash.Segment("a/b:c"), "a-b-c", "")
+	isEqual(t, dash.Segment("lpt1"), "-lpt1", "")
+	isEqual(t, dash.Segment("abcdefghij"), "abcdefgh", "")
+	isEqual(t, dash.Segment("abcdef. x"), "abcdef", "")
+	isEqual(t, dash.Segment("abcdeééé"), "abcdeé", "")
+
+	short := Sanitizer{Replacement: "_", MaxBytes: 3}
+	isEqual(t, short.Segment("CONSOLE"), "_CO", "")
+	isEqual(t, short.Segment("nul.txt"), "_nu", "")
+	isEqual(t, short.Segment("abcd"), "abc", "")
+
+	isEqual(t, Sanitizer{MaxBytes: 4}.Segment("COM1.txt"), "_COM", "")
+	isEqual(t, Sanitizer{Replacement: "/"}.Segment("a:b"), "a_b", "")
+	isEqual(t, Sanitizer{Replacement: "\\"}.Segment("a:b"), "a_b", "")
+	isEqual(t, Sanitizer{Replacement: "<->"}.Segment("a:b"), "a_-_b", "")
+	isEqual(t, Sanitizer{Replacement: "/"}.Segment("nul"), "_nul", "")
+	isEqual(t, Sanitizer{Replacement: "-", MaxBytes: 5}.Segment("Aux. x"), "-Aux", "")
+}
+
+func TestSanitizeLongSegment(t *testing.T) {
+	long := strings.Repeat("é", 200) // 400 bytes
+	safe := SanitizeSegment(long)
+	isEqual(t, len(safe), 254, "")
+	isEqual(t, utf8.ValidString(safe), true, "")
+}
+
+func TestPathSanitize(t *testing.T) {
+	isEqual(t, Path("/uploads/../a:b/CON/x?.txt/").Sanitize(), Path("/uploads/a_b/_CON/x_.txt"), "")
+	isEqual(t, Path("a//b. /..").Sanitize(), Path("a/b"), "")
+	isEqual(t, Path("/").Sanitize(), Path("/"), "")
+	isEqual(t, Path("").Sanitize(), Path(""), "")
+}
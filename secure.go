@@ -0,0 +1,108 @@
+package path
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ErrEscapesBase indicates that joining untrusted elements to a base path
+// would have produced a path outside the base. Use errors.Is to test for it.
+var ErrEscapesBase = errors.New("path escapes its base")
+
+// EscapeError is returned when an untrusted element would escape its base path.
+type EscapeError struct {
+	Base string // the base path
+	Elem string // the element that escapes
+}
+
+func (e *EscapeError) Error() string {
+	return fmt.Sprintf("%q escapes base %q", e.Elem, e.Base)
+}
+
+// Is reports whether target is ErrEscapesBase.
+func (e *EscapeError) Is(target error) bool {
+	return target == ErrEscapesBase
+}
+
+// SecureJoiner joins untrusted path elements to a trusted base path, guaranteeing
+// that the result lies within the base. The zero value is ready to use and
+// rejects any element that would escape.
+type SecureJoiner struct {
+	// Clamp causes ".." segments that would go above the base to be dropped,
+	// in the same way that Clean treats "/.." at the root. Otherwise, they are
+	// rejected with an *EscapeError.
+	Clamp bool
+
+	// Unescape causes each element to be percent-decoded once before it is
+	// checked, so that "%2e%2e%2f" is treated as "../". Elements containing
+	// malformed escapes are rejected.
+	Unescape bool
+
+	// Backslash causes backslashes to be treated as separators, as they are
+	// on Windows, so that "..\\x" is treated as "../x".
+	Backslash bool
+}
+
+// Join joins any number of untrusted elements to the base path. The result is
+// Cleaned, and it is always the same as base or inside it (see IsWithin).
+// Absolute elements are treated as relative to the base.
+//
+// If an element would escape the base, the result depends on Clamp. A ".."
+// that is later followed by a segment that returns into the base is still
+// treated as an escape.
+func (j SecureJoiner) Join(base string, elem ...string) (string, error) {
+	var stack []string
+
+	for _, e := range elem {
+		s := e
+		if j.Unescape {
+			u, err := url.PathUnescape(s)
+			if err != nil {
+				return "", err
+			}
+			s = u
+		}
+		if j.Backslash {
+			s = strings.ReplaceAll(s, `\`, "/")
+		}
+
+		for seg := range strings.SplitSeq(s, "/") {
+			switch seg {
+			case "", ".":
+				// ignored
+			case "..":
+				if len(stack) == 0 {
+					if !j.Clamp {
+						return "", &EscapeError{Base: base, Elem: e}
+					}
+				} else {
+					stack = stack[:len(stack)-1]
+				}
+			default:
+				stack = append(stack, seg)
+			}
+		}
+	}
+
+	return Join(base, strings.Join(stack, "/")), nil
+}
+
+// SecureJoin joins any number of untrusted elements to the base path, guaranteeing
+// that the result lies within the base. If an element would escape the base,
+// the error is an *EscapeError, which matches ErrEscapesBase.
+//
+// It is the same as SecureJoiner{}.Join; use a SecureJoiner for other options.
+func SecureJoin(base string, elem ...string) (string, error) {
+	return SecureJoiner{}.Join(base, elem...)
+}
+
+// Within joins any number of untrusted elements to the path, guaranteeing that
+// the result lies within the path. Unlike Append, an element such as "../x"
+// cannot escape: the error is an *EscapeError, which matches ErrEscapesBase.
+// See SecureJoin.
+func (path Path) Within(elem ...string) (Path, error) {
+	p, err := SecureJoin(string(path), elem...)
+	return Path(p), err
+}
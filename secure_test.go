@@ -0,0 +1,112 @@
+package path
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSecureJoin(t *testing.T) {
+	cases := []struct {
+		base     string
+		elem     []string
+		expected string
+	}{
+		{"/srv/data", []string{"a", "b.txt"}, "/srv/data/a/b.txt"},
+		{"/srv/data", []string{"/etc/passwd"}, "/srv/data/etc/passwd"},
+		{"/srv/data", []string{"a/../b"}, "/srv/data/b"},
+		{"/srv/data", []string{"a", "..", "b"}, "/srv/data/b"},
+		{"/srv/data", []string{"..%2fetc"}, "/srv/data/..%2fetc"},
+		{"/srv/data", []string{`..\etc`}, `/srv/data/..\etc`},
+		{"/srv/data", nil, "/srv/data"},
+		{"/", []string{"a"}, "/a"},
+		{"data", []string{"a"}, "data/a"},
+		{"", []string{"a"}, "a"},
+	}
+
+	for _, c := range cases {
+		actual, err := SecureJoin(c.base, c.elem...)
+		isNil(t, err, c)
+		isEqual(t, actual, c.expected, c)
+	}
+}
+
+func TestSecureJoinEscapes(t *testing.T) {
+	cases := []struct {
+		base string
+		elem []string
+	}{
+		{"/srv/data", []string{"../../etc/passwd"}},
+		{"/srv/data", []string{"a", "../.."}},
+		{"/srv/data", []string{"../data/x"}},
+		{"/", []string{".."}},
+	}
+
+	for _, c := range cases {
+		_, err := SecureJoin(c.base, c.elem...)
+		isEqual(t, errors.Is(err, ErrEscapesBase), true, c)
+		var ee *EscapeError
+		isEqual(t, errors.As(err, &ee), true, c)
+		isEqual(t, ee.Base, c.base, c)
+	}
+
+	_, err := SecureJoin("/srv/data", "a", "../../etc")
+	isEqual(t, err.Error(), `"../../etc" escapes base "/srv/data"`, "")
+}
+
+func TestSecureJoinerOptions(t *testing.T) {
+	cases := []struct {
+		joiner   SecureJoiner
+		elem     string
+		expected string
+		escapes  bool
+	}{
+		{SecureJoiner{Clamp: true}, "../../etc/passwd", "/srv/data/etc/passwd", false},
+		{SecureJoiner{Clamp: true}, "a/../../b", "/srv/data/b", false},
+		{SecureJoiner{Unescape: true}, "%2e%2e%2fetc", "", true},
+		{SecureJoiner{Unescape: true}, "a%2Fb", "/srv/data/a/b", false},
+		{SecureJoiner{Unescape: true, Clamp: true}, "%2e%2e/%2e%2e/etc", "/srv/data/etc", false},
+		{SecureJoiner{Backslash: true}, `..\..\etc`, "", true},
+		{SecureJoiner{Backslash: true}, `a\b`, "/srv/data/a/b", false},
+		{SecureJoiner{}, "%2e%2e/etc", "/srv/data/%2e%2e/etc", false},
+	}
+
+	for _, c := range cases {
+		actual, err := c.joiner.Join("/srv/data", c.elem)
+		isEqual(t, actual, c.expected, c)
+		isEqual(t, errors.Is(err, ErrEscapesBase), c.escapes, c)
+	}
+
+	_, err := SecureJoiner{Unescape: true}.Join("/srv/data", "%zz")
+	isEqual(t, err != nil && !errors.Is(err, ErrEscapesBase), true, "")
+}
+
+func TestPathWithin(t *testing.T) {
+	p, err := Path("/srv/data").Within("a", "b.txt")
+	isNil(t, err, "")
+	isEqual(t, p, Path("/srv/data/a/b.txt"), "")
+
+	_, err = Path("/srv/data").Within("../../etc/passwd")
+	isEqual(t, errors.Is(err, ErrEscapesBase), true, "")
+}
+
+func FuzzSecureJoin(f *testing.F) {
+	f.Add("/srv/data", "../../etc/passwd", uint8(0))
+	f.Add("/srv/data", "a/../b", uint8(1))
+	f.Add("/", "%2e%2e%2f%2e%2e", uint8(3))
+	f.Add("data", `..\..\x`, uint8(7))
+	f.Add("", "/a/./b/../../..", uint8(1))
+
+	f.Fuzz(func(t *testing.T, base, elem string, flags uint8) {
+		j := SecureJoiner{Clamp: flags&1 != 0, Unescape: flags&2 != 0, Backslash: flags&4 != 0}
+		p, err := j.Join(base, elem)
+		if err != nil {
+			if j.Clamp && errors.Is(err, ErrEscapesBase) {
+				t.Errorf("clamped join of %q to %q escaped", elem, base)
+			}
+			return
+		}
+		if !Path(p).IsWithin(Path(base)) {
+			t.Errorf("join of %q to %q gave %q, which is outside the base", elem, base, p)
+		}
+	})
+}
@@ -0,0 +1,175 @@
+package path
+
+import (
+	"strings"
+)
+
+// Len returns the number of segments in the path, i.e. the length of Segments.
+func (path Path) Len() int {
+	if path == "" || path == "/" {
+		return 0
+	}
+	s, _ := path.trimmed()
+	return strings.Count(s, "/") + 1
+}
+
+// index converts a possibly-negative segment index into an ordinary index,
+// returning false if it is out of range. Negative indices count from the end.
+func (path Path) index(i int) (int, bool) {
+	n := path.Len()
+	if i < 0 {
+		i += n
+	}
+	return i, 0 <= i && i < n
+}
+
+// Segment returns the ith segment, as for Segments()[i]. A negative index
+// counts from the end, so Segment(-1) is the last segment. If the index is
+// out of range, the result is "".
+func (path Path) Segment(i int) string {
+	i, ok := path.index(i)
+	if !ok {
+		return ""
+	}
+	for j, seg := range path.All() {
+		if j == i {
+			return seg
+		}
+	}
+	return ""
+}
+
+// Slice returns the segments from i up to but not including j. The result has
+// a leading slash unless i is zero and the path is relative, so for a clean
+// path it is the same as Drop(i).Take(j-i). Negative indices count from the
+// end; indices beyond the end are treated as Len.
+func (path Path) Slice(i, j int) Path {
+	n := path.Len()
+	i, j = clampIndex(i, n), clampIndex(j, n)
+	if j <= i {
+		return ""
+	}
+	return path[path.cut(i):path.cut(j)]
+}
+
+func clampIndex(i, n int) int {
+	if i < 0 {
+		i += n
+	}
+	return max(0, min(i, n))
+}
+
+// cut returns the offset of the slash before the kth segment, or of the end of
+// the last segment if k is Len. Empty segments are counted, as for Len, so the
+// cut points of "a//b" are 0, 1, 2 and 4.
+func (path Path) cut(k int) int {
+	if k <= 0 {
+		return 0
+	}
+	s, start := path.trimmed()
+	i := 0
+	for {
+		slash := strings.IndexByte(s[i:], '/')
+		if slash < 0 {
+			return start + len(s)
+		}
+		i += slash
+		k--
+		if k == 0 {
+			return start + i
+		}
+		i++
+	}
+}
+
+// DivideRight divides a path at the nth slash counting from the end, so that
+// the tail holds the last n segments, as counted by Len. If n is zero or less,
+// the tail is empty. Unlike Divide, empty segments such as the one in "a//b"
+// are counted like any other.
+//
+// The resulting pair (head, tail) always satisfy
+//
+//	head + tail = path
+func (path Path) DivideRight(n int) (Path, Path) {
+	if n <= 0 {
+		return path, ""
+	}
+	pivot := path.cut(max(0, path.Len()-n))
+	return path[:pivot], path[pivot:]
+}
+
+// DropLast is a helper for DivideRight that returns the head part only.
+func (path Path) DropLast(unwanted int) Path {
+	head, _ := path.DivideRight(unwanted)
+	return head
+}
+
+// TakeLast is a helper for DivideRight that returns the tail part only.
+func (path Path) TakeLast(wanted int) Path {
+	_, tail := path.DivideRight(wanted)
+	return tail
+}
+
+// ReplaceSegment returns the path with the ith segment replaced by s.
+// A negative index counts from the end. If the index is out of range,
+// the path is returned unchanged. Any leading or trailing slash is kept.
+func (path Path) ReplaceSegment(i int, s string) Path {
+	i, ok := path.index(i)
+	if !ok {
+		return path
+	}
+	segs := path.Segments()
+	segs[i] = s
+	return path.rebuild(segs)
+}
+
+// InsertSegment returns the path with the segments inserted before the ith
+// segment. An index of Len appends them. A negative index counts from the end,
+// so InsertSegment(-1, s) inserts s before the last segment. If the index is
+// out of range, the path is returned unchanged. Any leading or trailing slash
+// is kept.
+func (path Path) InsertSegment(i int, s ...string) Path {
+	n := path.Len()
+	if i < 0 {
+		i += n
+	}
+	if i < 0 || i > n {
+		return path
+	}
+	old := path.Segments()
+	segs := make([]string, 0, len(old)+len(s))
+	segs = append(segs, old[:i]...)
+	segs = append(segs, s...)
+	segs = append(segs, old[i:]...)
+	return path.rebuild(segs)
+}
+
+// RemoveSegment returns the path without its ith segment. A negative index
+// counts from the end. If the index is out of range, the path is returned
+// unchanged. Any leading slash is kept, as is any trailing slash unless no
+// segments remain.
+func (path Path) RemoveSegment(i int) Path {
+	i, ok := path.index(i)
+	if !ok {
+		return path
+	}
+	segs := path.Segments()
+	segs = append(segs[:i], segs[i+1:]...)
+	return path.rebuild(segs)
+}
+
+// rebuild joins segments, adding the leading and trailing slashes of the path.
+func (path Path) rebuild(segs []string) Path {
+	b := &strings.Builder{}
+	if path.IsAbs() {
+		b.WriteByte('/')
+	}
+	if len(segs) == 0 {
+		return Path(b.String())
+	}
+	b.WriteString(strings.Join(segs, "/"))
+	if len(path) > 1 && strings.HasSuffix(string(path), "/") {
+		b.WriteByte('/')
+	}
+	return Path(b.String())
+}
@@ -0,0 +1,113 @@
+package path
+
+import (
+	"testing"
+)
+
+func TestPathLenAndSegment(t *testing.T) {
+	cases := []Path{"", "/", "a", "/a", "/a/", "a/b/c", "/a/b/c/", "a//b", "//"}
+
+	for _, p := range cases {
+		segs := p.Segments()
+		isEqual(t, p.Len(), len(segs), p)
+		for i, seg := range segs {
+			isEqual(t, p.Segment(i), seg, p)
+			isEqual(t, p.Segment(i-len(segs)), seg, p)
+		}
+		isEqual(t, p.Segment(len(segs)), "", p)
+		isEqual(t, p.Segment(-len(segs)-1), "", p)
+	}
+}
+
+func TestPathSlice(t *testing.T) {
+	cases := []struct {
+		path     Path
+		i, j     int
+		expected Path
+	}{
+		{"/a/b/c/d", 0, 2, "/a/b"},
+		{"/a/b/c/d", 1, 3, "/b/c"},
+		{"/a/b/c/d", 2, 4, "/c/d"},
+		{"/a/b/c/d", -2, -1, "/c"},
+		{"/a/b/c/d", 1, 10, "/b/c/d"},
+		{"/a/b/c/d", 3, 1, ""},
+		{"/a/b/c/d", 0, 0, ""},
+		{"a/b/c", 0, 1, "a"},
+		{"a/b/c", 1, 2, "/b"},
+		{"/a//b", 2, 3, "/b"},
+		{"/a//b", 1, 2, "/"},
+		{"/a//b", 0, 3, "/a//b"},
+		{"a//b/", 2, 3, "/b"},
+		{"a//b/", 0, 1, "a"},
+		{"a//b/", -2, 3, "//b"},
+	}
+
+	for _, c := range cases {
+		isEqual(t, c.path.Slice(c.i, c.j), c.expected, c)
+	}
+}
+
+func TestPathDivideRight(t *testing.T) {
+	cases := []struct {
+		path       Path
+		n          int
+		head, tail Path
+	}{
+		{"/a/b/c/d", 1, "/a/b/c", "/d"},
+		{"/a/b/c/d", 2, "/a/b", "/c/d"},
+		{"/a/b/c/d", 4, "", "/a/b/c/d"},
+		{"/a/b/c/d", 9, "", "/a/b/c/d"},
+		{"/a/b/c/d", 0, "/a/b/c/d", ""},
+		{"/a/b/c/d", -1, "/a/b/c/d", ""},
+		{"a/b/c/", 1, "a/b", "/c/"},
+		{"a", 1, "", "a"},
+		{"", 1, "", ""},
+		{"/a//b", 1, "/a/", "/b"},
+		{"/a//b", 2, "/a", "//b"},
+		{"/a//b", 3, "", "/a//b"},
+		{"a//b/", 1, "a/", "/b/"},
+		{"a//b/", 2, "a", "//b/"},
+		{"a//b/", 3, "", "a//b/"},
+	}
+
+	for _, c := range cases {
+		head, tail := c.path.DivideRight(c.n)
+		isEqual(t, head, c.head, c)
+		isEqual(t, tail, c.tail, c)
+		isEqual(t, head+tail, c.path, c)
+		isEqual(t, c.path.DropLast(c.n), c.head, c)
+		isEqual(t, c.path.TakeLast(c.n), c.tail, c)
+	}
+}
+
+func TestPathReplaceSegment(t *testing.T) {
+	isEqual(t, Path("/a/b/c").ReplaceSegment(1, "x"), Path("/a/x/c"), "")
+	isEqual(t, Path("a/b/c/").ReplaceSegment(-1, "x"), Path("a/b/x/"), "")
+	isEqual(t, Path("/a").ReplaceSegment(0, "x"), Path("/x"), "")
+	isEqual(t, Path("/a/b").ReplaceSegment(2, "x"), Path("/a/b"), "")
+	isEqual(t, Path("/").ReplaceSegment(0, "x"), Path("/"), "")
+}
+
+func TestPathInsertSegment(t *testing.T) {
+	isEqual(t, Path("/a/b").InsertSegment(0, "x"), Path("/x/a/b"), "")
+	isEqual(t, Path("/a/b").InsertSegment(1, "x", "y"), Path("/a/x/y/b"), "")
+	isEqual(t, Path("/a/b/").InsertSegment(2, "x"), Path("/a/b/x/"), "")
+	isEqual(t, Path("a/b").InsertSegment(-1, "x"), Path("a/x/b"), "")
+	isEqual(t, Path("/").InsertSegment(0, "x"), Path("/x"), "")
+	isEqual(t, Path("").InsertSegment(0, "x"), Path("x"), "")
+	isEqual(t, Path("/a").InsertSegment(2, "x"), Path("/a"), "")
+	isEqual(t, Path("/a").InsertSegment(-2, "x"), Path("/a"), "")
+
+	extra := make([]string, 1, 4)
+	extra[0] = "x"
+	Path("/a/b").InsertSegment(1, extra...)
+	isEqual(t, extra[:2], []string{"x", ""}, "")
+}
+
+func TestPathRemoveSegment(t *testing.T) {
+	isEqual(t, Path("/a/b/c").RemoveSegment(1), Path("/a/c"), "")
+	isEqual(t, Path("a/b/c/").RemoveSegment(-1), Path("a/b/"), "")
+	isEqual(t, Path("/a/").RemoveSegment(0), Path("/"), "")
+	isEqual(t, Path("a").RemoveSegment(0), Path(""), "")
+	isEqual(t, Path("/a").RemoveSegment(1), Path("/a"), "")
+}
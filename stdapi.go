@@ -111,6 +111,8 @@ func Dir(path string) string {
 // Match requires pattern to match all of name, not just a substring.
 // The only possible returned error is ErrBadPattern, when pattern
 // is malformed.
+//
+// See also MatchDeep, which allows '**' to match across slashes.
 func Match(pattern, name string) (matched bool, err error) {
 	return std.Match(pattern, name)
 }
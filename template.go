@@ -0,0 +1,310 @@
+package path
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Template is a route template such as "/users/{id}/posts/{slug...}". It
+// consists of literal segments and parameters, each parameter being a whole
+// segment enclosed in braces:
+//
+//	{name}        matches any non-empty segment
+//	{name:re}     matches a segment that matches the regular expression re in its entirety
+//	{name...}     a catch-all that matches the rest of the path, including any slashes;
+//	              it must be the last segment and may match nothing
+//	{name...:re}  a catch-all whose value must match the regular expression re
+//
+// Parameter names must be unique and consist of letters, digits and
+// underscores. A Template is safe for concurrent use.
+type Template struct {
+	source   string
+	segments []templateSegment
+}
+
+type templateSegment struct {
+	text     string // a literal segment, or the name of a parameter
+	param    bool
+	catchAll bool
+	re       *regexp.Regexp // optional constraint
+	source   string         // the constraint as written
+}
+
+// Param is a named value captured from a path by a template.
+type Param struct {
+	Name, Value string
+}
+
+// Params holds the parameters captured by a template, in the order they
+// appear in the template.
+type Params []Param
+
+// Get returns the value of the named parameter, or "" if there is none.
+func (ps Params) Get(name string) string {
+	v, _ := ps.Lookup(name)
+	return v
+}
+
+// Lookup returns the value of the named parameter and whether it was present.
+func (ps Params) Lookup(name string) (string, bool) {
+	for _, p := range ps {
+		if p.Name == name {
+			return p.Value, true
+		}
+	}
+	return "", false
+}
+
+// Map returns the parameters as a map from name to value.
+func (ps Params) Map() map[string]string {
+	m := make(map[string]string, len(ps))
+	for _, p := range ps {
+		m[p.Name] = p.Value
+	}
+	return m
+}
+
+// TemplateError describes a problem with a template. Offset is the byte
+// position in the template where the problem was found.
+type TemplateError struct {
+	Template string
+	Offset   int
+	Message  string
+	Err      error // the underlying error, if any
+}
+
+func (e *TemplateError) Error() string {
+	msg := fmt.Sprintf("bad template %q at offset %d: %s", e.Template, e.Offset, e.Message)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *TemplateError) Unwrap() error {
+	return e.Err
+}
+
+// ParamError describes a parameter value that cannot be used to expand a template.
+type ParamError struct {
+	Template string
+	Name     string
+	Value    string
+	Message  string
+}
+
+func (e *ParamError) Error() string {
+	return fmt.Sprintf("cannot expand template %q: parameter %q %s", e.Template, e.Name, e.Message)
+}
+
+// ParseTemplate parses a route template. If the template is malformed, the
+// error is a *TemplateError.
+func ParseTemplate(template string) (*Template, error) {
+	t := &Template{source: template}
+	names := make(map[string]bool)
+
+	fail := func(offset int, message string, err error) (*Template, error) {
+		return nil, &TemplateError{Template: template, Offset: offset, Message: message, Err: err}
+	}
+
+	for start := 0; start <= len(template); {
+		if n := len(t.segments); n > 0 && t.segments[n-1].catchAll {
+			return fail(start-1, "catch-all parameter must be the last segment", nil)
+		}
+
+		rest := template[start:]
+		if !strings.HasPrefix(rest, "{") {
+			seg, _, _ := strings.Cut(rest, "/")
+			if i := strings.IndexAny(seg, "{}"); i >= 0 {
+				return fail(start+i, "a parameter must be a whole segment", nil)
+			}
+			t.segments = append(t.segments, templateSegment{text: seg})
+			start += len(seg) + 1
+			continue
+		}
+
+		end := closingBrace(rest)
+		if end < 0 {
+			return fail(start, "unbalanced braces", nil)
+		}
+		if end+1 < len(rest) && rest[end+1] != '/' {
+			return fail(start+end+1, "a parameter must be a whole segment", nil)
+		}
+
+		name, constraint, hasConstraint := strings.Cut(rest[1:end], ":")
+		seg := templateSegment{param: true}
+		seg.text, seg.catchAll = strings.CutSuffix(name, "...")
+
+		if !isParamName(seg.text) {
+			return fail(start+1, fmt.Sprintf("invalid parameter name %q", seg.text), nil)
+		}
+		if names[seg.text] {
+			return fail(start+1, fmt.Sprintf("duplicate parameter name %q", seg.text), nil)
+		}
+		names[seg.text] = true
+
+		if hasConstraint {
+			re, err := regexp.Compile("^(?:" + constraint + ")$")
+			if err != nil {
+				return fail(start+len(name)+2, "invalid constraint", err)
+			}
+			seg.re, seg.source = re, constraint
+		}
+
+		t.segments = append(t.segments, seg)
+		start += end + 2
+	}
+
+	return t, nil
+}
+
+// MustParseTemplate is like ParseTemplate but panics if the template is malformed.
+func MustParseTemplate(template string) *Template {
+	t, err := ParseTemplate(template)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// closingBrace finds the brace that closes the one at the start of s,
+// allowing for nested braces within regular expressions.
+func closingBrace(s string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func isParamName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// Match reports whether the path matches the template and, if so, returns
+// the parameter values. The path must match the whole template; in
+// particular, a trailing slash is significant.
+//
+// Each parameter value is unescaped, as for MuxPattern.Match, before it is
+// checked against its constraint, so Match recovers the values given to
+// Expand. A value containing a malformed escape is used as it is.
+func (t *Template) Match(path Path) (Params, bool) {
+	var params Params
+	name := string(path)
+
+	for i, seg := range t.segments {
+		if seg.catchAll {
+			value := unescapeMux(name)
+			if seg.re != nil && !seg.re.MatchString(value) {
+				return nil, false
+			}
+			return append(params, Param{Name: seg.text, Value: value}), true
+		}
+
+		head, tail, more := strings.Cut(name, "/")
+		if seg.param {
+			value := unescapeMux(head)
+			if head == "" || (seg.re != nil && !seg.re.MatchString(value)) {
+				return nil, false
+			}
+			params = append(params, Param{Name: seg.text, Value: value})
+		} else if head != seg.text {
+			return nil, false
+		}
+
+		last := i == len(t.segments)-1
+		if more == last {
+			return nil, false
+		}
+		name = tail
+	}
+
+	return params, true
+}
+
+// Expand builds a path from the template by substituting the supplied values
+// for its parameters. Each value is escaped so that it forms exactly one
+// segment, even if it contains slashes. The value of a catch-all parameter is
+// split at its slashes and each part is escaped separately, so it may form
+// many segments.
+//
+// Every parameter other than a catch-all must have a non-empty value, and
+// each value must satisfy its constraint, if any; otherwise the error is a
+// *ParamError. Values for names not in the template are ignored.
+func (t *Template) Expand(values map[string]string) (Path, error) {
+	b := &strings.Builder{}
+
+	for i, seg := range t.segments {
+		if i > 0 {
+			b.WriteByte('/')
+		}
+
+		if !seg.param {
+			b.WriteString(seg.text)
+			continue
+		}
+
+		value, ok := values[seg.text]
+		fail := func(message string) (Path, error) {
+			return "", &ParamError{Template: t.source, Name: seg.text, Value: value, Message: message}
+		}
+
+		switch {
+		case !ok && !seg.catchAll:
+			return fail("is missing")
+		case value == "" && !seg.catchAll:
+			return fail("is empty")
+		case seg.re != nil && !seg.re.MatchString(value):
+			return fail(fmt.Sprintf("value %q does not match %s", value, seg.source))
+		}
+
+		if seg.catchAll {
+			for j, part := range strings.Split(value, "/") {
+				if j > 0 {
+					b.WriteByte('/')
+				}
+				b.WriteString(PathEscape(part))
+			}
+		} else {
+			b.WriteString(PathEscape(value))
+		}
+	}
+
+	return Path(b.String()), nil
+}
+
+// Names returns the names of the parameters in the template, in order.
+func (t *Template) Names() []string {
+	var names []string
+	for _, seg := range t.segments {
+		if seg.param {
+			names = append(names, seg.text)
+		}
+	}
+	return names
+}
+
+// String returns the source text of the template.
+func (t *Template) String() string {
+	return t.source
+}
@@ -0,0 +1,144 @@
+package path
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTemplateMatch(t *testing.T) {
+	cases := []struct {
+		template string
+		path     Path
+		expected Params
+		ok       bool
+	}{
+		{"/users/{id}", "/users/42", Params{{"id", "42"}}, true},
+		{"/users/{id}", "/users/42/", nil, false},
+		{"/users/{id}", "/users/", nil, false},
+		{"/users/{id}", "/users", nil, false},
+		{"/users/{id}/posts/{slug...}", "/users/7/posts/2024/hello", Params{{"id", "7"}, {"slug", "2024/hello"}}, true},
+		{"/users/{id}/posts/{slug...}", "/users/7/posts/", Params{{"id", "7"}, {"slug", ""}}, true},
+		{"/users/{id}/posts/{slug...}", "/users/7/posts", nil, false},
+		{"/users/{id:[0-9]+}", "/users/42", Params{{"id", "42"}}, true},
+		{"/users/{id:[0-9]+}", "/users/bob", nil, false},
+		{"/d/{day:[0-9]{2}}", "/d/07", Params{{"day", "07"}}, true},
+		{"/d/{day:[0-9]{2}}", "/d/7", nil, false},
+		{"/f/{rest...:[a-z/]+}", "/f/a/b", Params{{"rest", "a/b"}}, true},
+		{"/f/{rest...:[a-z/]+}", "/f/a/1", nil, false},
+		{"/static/", "/static/", nil, true},
+		{"/static/", "/static", nil, false},
+		{"a/{b}", "a/x", Params{{"b", "x"}}, true},
+		{"a/{b}", "/a/x", nil, false},
+		{"/users/{id}", "/users/a%2Fb%20c", Params{{"id", "a/b c"}}, true},
+		{"/users/{id}", "/users/100%", Params{{"id", "100%"}}, true},
+		{"/users/{id:[a-z]+}", "/users/%61", Params{{"id", "a"}}, true},
+		{"/f/{rest...}", "/f/a%2Fb/c%20d", Params{{"rest", "a/b/c d"}}, true},
+	}
+
+	for _, c := range cases {
+		tpl, err := ParseTemplate(c.template)
+		isNil(t, err, c.template)
+		params, ok := tpl.Match(c.path)
+		isEqual(t, ok, c.ok, c)
+		isEqual(t, params, c.expected, c)
+	}
+}
+
+func TestTemplateNames(t *testing.T) {
+	tpl := MustParseTemplate("/users/{id:[0-9]+}/posts/{slug...}")
+	isEqual(t, tpl.Names(), []string{"id", "slug"}, "")
+	isEqual(t, tpl.String(), "/users/{id:[0-9]+}/posts/{slug...}", "")
+}
+
+func TestParams(t *testing.T) {
+	ps := Params{{"id", "42"}, {"slug", "a/b"}}
+	isEqual(t, ps.Get("id"), "42", "")
+	isEqual(t, ps.Get("x"), "", "")
+	v, ok := ps.Lookup("slug")
+	isEqual(t, v, "a/b", "")
+	isEqual(t, ok, true, "")
+	isEqual(t, ps.Map(), map[string]string{"id": "42", "slug": "a/b"}, "")
+}
+
+func TestParseTemplateErrors(t *testing.T) {
+	cases := []struct {
+		template string
+		offset   int
+		message  string
+	}{
+		{"/users/{id", 7, "unbalanced braces"},
+		{"/users/x{id}", 8, "a parameter must be a whole segment"},
+		{"/users/{id}x", 11, "a parameter must be a whole segment"},
+		{"/users/id}", 9, "a parameter must be a whole segment"},
+		{"/users/{}", 8, `invalid parameter name ""`},
+		{"/users/{a-b}", 8, `invalid parameter name "a-b"`},
+		{"/users/{id}/{id}", 13, `duplicate parameter name "id"`},
+		{"/files/{rest...}/x", 16, "catch-all parameter must be the last segment"},
+		{"/users/{id:[0-9}", 11, "invalid constraint"},
+	}
+
+	for _, c := range cases {
+		_, err := ParseTemplate(c.template)
+		var te *TemplateError
+		isEqual(t, errors.As(err, &te), true, c.template)
+		isEqual(t, te.Template, c.template, c.template)
+		isEqual(t, te.Offset, c.offset, c.template)
+		isEqual(t, te.Message, c.message, c.template)
+	}
+}
+
+func TestTemplateErrorMessage(t *testing.T) {
+	_, err := ParseTemplate("/users/{id:(}")
+	isEqual(t, err.Error(), "bad template \"/users/{id:(}\" at offset 11: invalid constraint: error parsing regexp: missing closing ): `^(?:()$`", "")
+}
+
+func TestTemplateExpand(t *testing.T) {
+	cases := []struct {
+		template string
+		values   map[string]string
+		expected Path
+	}{
+		{"/users/{id}", map[string]string{"id": "42"}, "/users/42"},
+		{"/users/{id}", map[string]string{"id": "a/b c"}, "/users/a%2Fb%20c"},
+		{"/users/{id}", map[string]string{"id": ".."}, "/users/%2E%2E"},
+		{"/users/{id}/files/{rest...}", map[string]string{"id": "7", "rest": "a b/c/d.txt"}, "/users/7/files/a%20b/c/d.txt"},
+		{"/users/{id}/files/{rest...}", map[string]string{"id": "7"}, "/users/7/files/"},
+		{"/users/{id:[0-9]+}", map[string]string{"id": "42", "other": "x"}, "/users/42"},
+		{"/f/{name:[a-z ]+}", map[string]string{"name": "a b"}, "/f/a%20b"},
+		{"/f/{rest...:[a-z /]+}", map[string]string{"rest": "a b/c"}, "/f/a%20b/c"},
+		{"/static/", nil, "/static/"},
+	}
+
+	for _, c := range cases {
+		tpl := MustParseTemplate(c.template)
+		p, err := tpl.Expand(c.values)
+		isNil(t, err, c)
+		isEqual(t, p, c.expected, c)
+
+		params, ok := tpl.Match(p)
+		isEqual(t, ok, true, c)
+		for _, name := range tpl.Names() {
+			isEqual(t, params.Get(name), c.values[name], c)
+		}
+	}
+}
+
+func TestTemplateExpandErrors(t *testing.T) {
+	cases := []struct {
+		template string
+		values   map[string]string
+		message  string
+	}{
+		{"/users/{id}", nil, `cannot expand template "/users/{id}": parameter "id" is missing`},
+		{"/users/{id}", map[string]string{"id": ""}, `cannot expand template "/users/{id}": parameter "id" is empty`},
+		{"/users/{id:[0-9]+}", map[string]string{"id": "bob"}, `cannot expand template "/users/{id:[0-9]+}": parameter "id" value "bob" does not match [0-9]+`},
+		{"/f/{rest...:[a-z/]+}", map[string]string{"rest": "a/1"}, `cannot expand template "/f/{rest...:[a-z/]+}": parameter "rest" value "a/1" does not match [a-z/]+`},
+	}
+
+	for _, c := range cases {
+		_, err := MustParseTemplate(c.template).Expand(c.values)
+		var pe *ParamError
+		isEqual(t, errors.As(err, &pe), true, c.template)
+		isEqual(t, err.Error(), c.message, c.template)
+	}
+}
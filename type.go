@@ -2,6 +2,7 @@ package path
 
 import (
 	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 	std "path"
 	"strings"
@@ -159,6 +160,8 @@ func (path Path) IsAbs() bool {
 }
 
 // HasPrefix reports whether the path starts with a particular prefix.
+// The comparison is byte by byte, so "/api/users2" has the prefix "/api/users".
+// See IsWithin for a comparison of whole segments.
 func (path Path) HasPrefix(other Path) bool {
 	return strings.HasPrefix(string(path), string(other))
 }
@@ -168,6 +171,164 @@ func (path Path) HasSuffix(other Path) bool {
 	return strings.HasSuffix(string(path), string(other))
 }
 
+// Match reports whether the path matches the shell file name pattern.
+// The pattern syntax is as for MatchDeep, so '**' matches zero or more
+// whole segments.
+//
+// The returned error is ErrBadPattern when pattern is malformed, or wraps
+// ErrBadPattern when its brace terms expand too far (see Expand).
+func (path Path) Match(pattern string) (matched bool, err error) {
+	return MatchDeep(pattern, string(path))
+}
+
+// IsWithin reports whether the path is the same as base or lies inside it.
+// Unlike HasPrefix, whole segments are compared, so "/api/users2" is not within
+// "/api/users". Both paths are cleaned first, so trailing slashes, "." and ".."
+// segments are handled consistently.
+//
+// An absolute path is never within a relative one, nor vice versa. The root "/"
+// contains every absolute path, and "." (or "") contains every relative path
+// that does not start with "..".
+func (path Path) IsWithin(base Path) bool {
+	_, ok := path.TrimPrefix(base)
+	return ok
+}
+
+// Contains reports whether the other path is the same as this path or lies inside it.
+// It is the converse of IsWithin.
+func (path Path) Contains(other Path) bool {
+	return other.IsWithin(path)
+}
+
+// IsAncestorOf reports whether the other path lies strictly inside this path,
+// i.e. Contains is true but the paths are not the same after cleaning.
+func (path Path) IsAncestorOf(other Path) bool {
+	rest, ok := other.TrimPrefix(path)
+	return ok && rest != "."
+}
+
+// TrimPrefix removes a prefix from the path, comparing whole segments in the
+// same way as IsWithin. If the path is within the prefix, it returns the
+// remaining relative path and true; this is "." when the paths are the same.
+// Otherwise it returns the cleaned path and false.
+//
+// For a non-empty prefix, the result satisfies prefix.Join(rest).Clean() == path.Clean().
+func (path Path) TrimPrefix(prefix Path) (rest Path, ok bool) {
+	p, b := path.Clean(), prefix.Clean()
+
+	switch {
+	case p.IsAbs() != b.IsAbs():
+		return p, false
+	case p == b:
+		return ".", true
+	case b == "/":
+		return p[1:], true
+	case b == ".":
+		if p == ".." || strings.HasPrefix(string(p), "../") {
+			return p, false
+		}
+		return p, true
+	case strings.HasPrefix(string(p), string(b)+"/"):
+		return p[len(b)+1:], true
+	}
+	return p, false
+}
+
+// Rel returns a relative path that is lexically equivalent to target when
+// joined to this path. That is, path.Join(rel).Clean() == target.Clean(),
+// except when this path is empty: Join treats the empty path as the root, so
+// use Of(path, rel) instead, which holds for every path.
+//
+// An error is returned if one path is absolute and the other is relative, or
+// if the result cannot be computed without knowing the current directory.
+// See Rel for details.
+func (path Path) Rel(target Path) (Path, error) {
+	rel, err := Rel(string(path), string(target))
+	return Path(rel), err
+}
+
+// Resolve resolves a relative reference against this path as the base, as for
+// URL paths, following RFC 3986 section 5.2. See Resolve for details.
+func (path Path) Resolve(ref Path) Path {
+	return Path(Resolve(string(path), string(ref)))
+}
+
+// CommonAncestor returns the deepest path that contains all of the paths, comparing
+// whole segments after cleaning each path. For example, the common ancestor of
+// "/a/b/c" and "/a/bc" is "/a".
+//
+// If all the paths are absolute, the result is absolute, and is "/" if they have
+// no leading segments in common. If all the paths are relative, the result is
+// relative, and is "." if they have no leading segments in common. If some paths
+// are absolute and others relative, or there are no paths, the result is "".
+func CommonAncestor(paths ...Path) Path {
+	if len(paths) == 0 {
+		return ""
+	}
+
+	first := paths[0].Clean()
+	common := first.Segments()
+	if first == "." {
+		common = nil
+	}
+
+	for _, p := range paths[1:] {
+		p = p.Clean()
+		if p.IsAbs() != first.IsAbs() {
+			return ""
+		}
+		n := 0
+		for seg := range p.Values() {
+			if n == len(common) || seg != common[n] {
+				break
+			}
+			n++
+		}
+		common = common[:n]
+	}
+
+	switch {
+	case first.IsAbs():
+		return "/" + Path(strings.Join(common, "/"))
+	case len(common) == 0:
+		return "."
+	}
+	return Path(strings.Join(common, "/"))
+}
+
+// CommonSuffix returns the longest sequence of trailing segments shared by all of the
+// paths, comparing whole segments after cleaning each path. For example, the common
+// suffix of "/a/b/c.txt" and "x/b/c.txt" is "b/c.txt", but that of "/a/bc.txt" and
+// "/a/c.txt" is "".
+//
+// The result is always relative; it is "" if there are no paths, or no segments
+// are shared. The root "/" has no segments.
+func CommonSuffix(paths ...Path) Path {
+	if len(paths) == 0 {
+		return ""
+	}
+
+	first := paths[0].Clean()
+	common := first.Segments()
+	if first == "." {
+		common = nil
+	}
+
+	for _, p := range paths[1:] {
+		p = p.Clean()
+		n := 0
+		for _, seg := range p.Backward() {
+			if n == len(common) || seg != common[len(common)-1-n] {
+				break
+			}
+			n++
+		}
+		common = common[len(common)-n:]
+	}
+
+	return Path(strings.Join(common, "/"))
+}
+
 // Dir returns all but the last element of path, typically the path's directory.
 // After dropping the final element using Split, the path is Cleaned and trailing
 // slashes are removed.
@@ -247,22 +408,24 @@ func (path Path) String() string {
 
 // Scan parses some value. It implements sql.Scanner,
 // https://golang.org/pkg/database/sql/#Scanner
+//
+// SQL NULL gives the empty path. Strings are normalised and checked using
+// DefaultPolicy; if this fails, or the value has an unsupported type, the error
+// is an *InvalidPathError, which matches ErrInvalidPath, and the path is not altered.
 func (path *Path) Scan(value interface{}) error {
-	if value == nil {
+	var s string
+	switch v := value.(type) {
+	case nil:
 		*path = Path("")
 		return nil
-	}
-
-	switch value.(type) {
 	case string:
-		*path = Path(value.(string))
+		s = v
 	case []byte:
-		*path = Path(string(value.([]byte)))
-	case nil:
+		s = string(v)
 	default:
-		return fmt.Errorf("Path.Scan(%#v)", value)
+		return &InvalidPathError{Op: "Path.Scan", Value: value, Err: fmt.Errorf("unsupported type %T", value)}
 	}
-	return nil
+	return path.unmarshal("Path.Scan", s)
 }
 
 // Value converts the value to a string. It implements driver.Valuer,
@@ -270,3 +433,64 @@ func (path *Path) Scan(value interface{}) error {
 func (path Path) Value() (driver.Value, error) {
 	return string(path), nil
 }
+
+// MarshalText implements encoding.TextMarshaler.
+func (path Path) MarshalText() ([]byte, error) {
+	return []byte(path), nil
+}
+
+// AppendText implements encoding.TextAppender.
+func (path Path) AppendText(b []byte) ([]byte, error) {
+	return append(b, path...), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The text is normalised
+// and checked using DefaultPolicy, as for Scan.
+func (path *Path) UnmarshalText(text []byte) error {
+	return path.unmarshal("Path.UnmarshalText", string(text))
+}
+
+// MarshalJSON implements json.Marshaler. The path is encoded as a JSON string.
+func (path Path) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(path))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The path must be a JSON string;
+// null leaves the path unchanged. The string is normalised and checked using
+// DefaultPolicy, as for Scan.
+func (path *Path) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return &InvalidPathError{Op: "Path.UnmarshalJSON", Value: string(data), Err: err}
+	}
+	return path.unmarshal("Path.UnmarshalJSON", s)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding is the same
+// as for MarshalText.
+func (path Path) MarshalBinary() ([]byte, error) {
+	return path.MarshalText()
+}
+
+// AppendBinary implements encoding.BinaryAppender.
+func (path Path) AppendBinary(b []byte) ([]byte, error) {
+	return path.AppendText(b)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. The data is
+// normalised and checked using DefaultPolicy, as for Scan.
+func (path *Path) UnmarshalBinary(data []byte) error {
+	return path.unmarshal("Path.UnmarshalBinary", string(data))
+}
+
+func (path *Path) unmarshal(op, s string) error {
+	p, err := applyDefaultPolicy(op, s)
+	if err != nil {
+		return err
+	}
+	*path = p
+	return nil
+}
@@ -1,6 +1,10 @@
 package path
 
-import "testing"
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
 
 func TestPathClean(t *testing.T) {
 	a := Path("/a/b/..").Clean()
@@ -91,6 +95,92 @@ func TestPathHasSuffix(t *testing.T) {
 	isEqual(t, Path("/a/b/zz.png").HasSuffix("b/aa.png"), false, "")
 }
 
+func TestPathTrimPrefix(t *testing.T) {
+	cases := []struct {
+		path, prefix, rest Path
+		ok                 bool
+	}{
+		{"/api/users/42", "/api/users", "42", true},
+		{"/api/users/42", "/api/users/", "42", true},
+		{"/api/users/42/", "/api//users", "42", true},
+		{"/api/users", "/api/users", ".", true},
+		{"/api/users/", "/api/users", ".", true},
+		{"/api/users2", "/api/users", "/api/users2", false},
+		{"/api", "/api/users", "/api", false},
+		{"/api/x/../users/1", "/api/users", "1", true},
+		{"/api/users/../secret", "/api/users", "/api/secret", false},
+		{"/a/b", "/", "a/b", true},
+		{"/", "/", ".", true},
+		{"a/b", ".", "a/b", true},
+		{"a/b", "", "a/b", true},
+		{"a/b", "a", "b", true},
+		{"../a", ".", "../a", false},
+		{"..", "", "..", false},
+		{"/a/b", "a", "/a/b", false},
+		{"a/b", "/a", "a/b", false},
+		{"a/b", "/", "a/b", false},
+	}
+
+	for _, c := range cases {
+		rest, ok := c.path.TrimPrefix(c.prefix)
+		isEqual(t, rest, c.rest, c)
+		isEqual(t, ok, c.ok, c)
+		isEqual(t, c.path.IsWithin(c.prefix), c.ok, c)
+		isEqual(t, c.prefix.Contains(c.path), c.ok, c)
+		isEqual(t, c.prefix.IsAncestorOf(c.path), c.ok && rest != ".", c)
+		if ok && c.prefix != "" {
+			isEqual(t, c.prefix.Join(rest).Clean(), c.path.Clean(), c)
+		}
+	}
+}
+
+func TestCommonAncestor(t *testing.T) {
+	cases := []struct {
+		paths    []Path
+		expected Path
+	}{
+		{[]Path{"/a/b/c", "/a/b/d"}, "/a/b"},
+		{[]Path{"/a/b/c", "/a/bc"}, "/a"},
+		{[]Path{"/a/b/c/", "/a//b/./c/d", "/a/b/c/e/f"}, "/a/b/c"},
+		{[]Path{"/a/b/c"}, "/a/b/c"},
+		{[]Path{"/a/b", "/x/y"}, "/"},
+		{[]Path{"/a/b", "/"}, "/"},
+		{[]Path{"/", "/"}, "/"},
+		{[]Path{"a/b/c", "a/b/d"}, "a/b"},
+		{[]Path{"a/b", "x/y"}, "."},
+		{[]Path{"a/b", "."}, "."},
+		{[]Path{".", "a/b"}, "."},
+		{[]Path{"../a", "../b"}, ".."},
+		{[]Path{"/a/b", "a/b"}, ""},
+		{nil, ""},
+	}
+
+	for _, c := range cases {
+		isEqual(t, CommonAncestor(c.paths...), c.expected, c.paths)
+	}
+}
+
+func TestCommonSuffix(t *testing.T) {
+	cases := []struct {
+		paths    []Path
+		expected Path
+	}{
+		{[]Path{"/a/b/c.txt", "x/b/c.txt"}, "b/c.txt"},
+		{[]Path{"/a/bc.txt", "/a/c.txt"}, ""},
+		{[]Path{"/a/b/c/", "/x/b//c", "b/c"}, "b/c"},
+		{[]Path{"/a/b/c"}, "a/b/c"},
+		{[]Path{"/a/b", "/a/b"}, "a/b"},
+		{[]Path{"/a/b", "/"}, ""},
+		{[]Path{"/", "/"}, ""},
+		{[]Path{"a/b", "."}, ""},
+		{nil, ""},
+	}
+
+	for _, c := range cases {
+		isEqual(t, CommonSuffix(c.paths...), c.expected, c.paths)
+	}
+}
+
 func TestPathDir(t *testing.T) {
 	isEqual(t, Path("/a/b/zz.png").Dir(), Path("/a/b"), "")
 }
@@ -150,7 +240,9 @@ func TestPathScan(t *testing.T) {
 	isEqual(t, *a, Path("/a/b/c/zz.png"), "")
 
 	err = a.Scan(123)
-	isEqual(t, err.Error(), "Path.Scan(123)", "")
+	isEqual(t, err.Error(), "Path.Scan(123): unsupported type int", "")
+	isEqual(t, errors.Is(err, ErrInvalidPath), true, "")
+	isEqual(t, *a, Path("/a/b/c/zz.png"), "")
 }
 
 func TestPathValue(t *testing.T) {
@@ -158,3 +250,53 @@ func TestPathValue(t *testing.T) {
 	isNil(t, err, "")
 	isEqual(t, a, "/a/b/c/zz.png", "")
 }
+
+func TestPathText(t *testing.T) {
+	text, err := Path("/a/b c").MarshalText()
+	isNil(t, err, "")
+	isEqual(t, string(text), "/a/b c", "")
+
+	text, err = Path("/x").AppendText([]byte("prefix:"))
+	isNil(t, err, "")
+	isEqual(t, string(text), "prefix:/x", "")
+
+	var p Path
+	err = p.UnmarshalText([]byte("a//b/../c/"))
+	isNil(t, err, "")
+	isEqual(t, p, Path("a//b/../c/"), "")
+}
+
+func TestPathBinary(t *testing.T) {
+	data, err := Path("/a/b").MarshalBinary()
+	isNil(t, err, "")
+
+	var p Path
+	err = p.UnmarshalBinary(data)
+	isNil(t, err, "")
+	isEqual(t, p, Path("/a/b"), "")
+}
+
+func TestPathJSON(t *testing.T) {
+	type doc struct {
+		Path Path
+		Ptr  *Path
+	}
+
+	data, err := json.Marshal(doc{Path: "/a/\"b\"", Ptr: nil})
+	isNil(t, err, "")
+	isEqual(t, string(data), `{"Path":"/a/\"b\"","Ptr":null}`, "")
+
+	var d doc
+	err = json.Unmarshal(data, &d)
+	isNil(t, err, "")
+	isEqual(t, d.Path, Path(`/a/"b"`), "")
+	isEqual(t, d.Ptr, (*Path)(nil), "")
+
+	p := Path("/unchanged")
+	err = json.Unmarshal([]byte("null"), &p)
+	isNil(t, err, "")
+	isEqual(t, p, Path("/unchanged"), "")
+
+	err = json.Unmarshal([]byte("123"), &p)
+	isEqual(t, errors.Is(err, ErrInvalidPath), true, "")
+}
@@ -0,0 +1,46 @@
+package path
+
+import (
+	"net/url"
+)
+
+// FromURL returns the path of a URL in its escaped form, as given by
+// url.URL.EscapedPath. This uses RawPath when it is present, so an escaped
+// slash such as "a%2Fb" stays within its segment instead of becoming a separator.
+//
+// The path can be converted to an EscapedPath to get its decoded segments.
+func FromURL(u *url.URL) Path {
+	return Path(u.EscapedPath())
+}
+
+// ApplyTo sets the path of a URL, treating this path as being in escaped form,
+// as returned by FromURL. It sets u.Path to the decoded path and sets u.RawPath
+// only when it is needed, i.e. when the default encoding of u.Path would
+// differ, in the same way as url.URL does itself.
+//
+// It returns an error if the path contains a malformed escape, in which case
+// the URL is not altered.
+func (path Path) ApplyTo(u *url.URL) error {
+	decoded, err := PathUnescape(string(path))
+	if err != nil {
+		return err
+	}
+	u.Path = decoded
+	u.RawPath = ""
+	if u.EscapedPath() != string(path) {
+		u.RawPath = string(path)
+	}
+	return nil
+}
+
+// JoinURL returns a copy of the base URL with the elements appended to its path
+// using Append, so the result is Cleaned. The elements are in escaped form, like
+// the path of the base URL; use PathEscape or EscapedPath.Append to escape
+// arbitrary values. The base URL is not altered.
+func JoinURL(base *url.URL, elem ...string) (*url.URL, error) {
+	u := *base
+	if err := FromURL(base).Append(elem...).ApplyTo(&u); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
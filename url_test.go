@@ -0,0 +1,60 @@
+package path
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestFromURL(t *testing.T) {
+	u, _ := url.Parse("https://example.com/files/a%2Fb/c%20d")
+	isEqual(t, FromURL(u), Path("/files/a%2Fb/c%20d"), "")
+	isEqual(t, EscapedPath(FromURL(u)).Segments(), []string{"files", "a/b", "c d"}, "")
+
+	u, _ = url.Parse("https://example.com/plain/path")
+	isEqual(t, FromURL(u), Path("/plain/path"), "")
+
+	isEqual(t, FromURL(&url.URL{}), Path(""), "")
+}
+
+func TestPathApplyTo(t *testing.T) {
+	cases := []struct {
+		path, expectedPath, expectedRaw string
+	}{
+		{"/plain/path", "/plain/path", ""},
+		{"/a%20b", "/a b", ""},
+		{"/a%2Fb/c", "/a/b/c", "/a%2Fb/c"},
+		{"/caf%C3%A9", "/café", ""},
+		{"", "", ""},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse("https://example.com/old?q=1")
+		err := Path(c.path).ApplyTo(u)
+		isNil(t, err, c)
+		isEqual(t, u.Path, c.expectedPath, c)
+		isEqual(t, u.RawPath, c.expectedRaw, c)
+		isEqual(t, u.EscapedPath(), c.path, c)
+		isEqual(t, u.RawQuery, "q=1", c)
+	}
+
+	u, _ := url.Parse("https://example.com/old")
+	err := Path("/bad%zz").ApplyTo(u)
+	isEqual(t, err != nil, true, "")
+	isEqual(t, u.Path, "/old", "")
+}
+
+func TestJoinURL(t *testing.T) {
+	base, _ := url.Parse("https://example.com/api/a%2Fb/?x=1")
+
+	u, err := JoinURL(base, "users", "../items", "c%2Fd")
+	isNil(t, err, "")
+	isEqual(t, u.String(), "https://example.com/api/a%2Fb/items/c%2Fd?x=1", "")
+	isEqual(t, base.String(), "https://example.com/api/a%2Fb/?x=1", "")
+
+	u, err = JoinURL(&url.URL{Scheme: "http", Host: "h"}, "x")
+	isNil(t, err, "")
+	isEqual(t, u.String(), "http://h/x", "")
+
+	_, err = JoinURL(base, "%zz")
+	isEqual(t, err != nil, true, "")
+}
@@ -0,0 +1,250 @@
+package path
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Violation describes one way in which a path breaks a validation rule.
+type Violation struct {
+	// Segment is the index of the offending segment, as for Segments,
+	// or -1 if the violation concerns the whole path.
+	Segment int
+	// Value is the offending segment, or the whole path.
+	Value string
+	// Reason explains the violation.
+	Reason string
+}
+
+func (v Violation) String() string {
+	if v.Segment < 0 {
+		return v.Reason
+	}
+	return fmt.Sprintf("segment %d %q %s", v.Segment, v.Value, v.Reason)
+}
+
+// ValidationError is returned when a path breaks one or more validation rules.
+// It lists every violation, in the order of the rules that found them.
+type ValidationError struct {
+	Path       Path
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "invalid path %q: ", string(e.Path))
+	for i, v := range e.Violations {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(v.String())
+	}
+	return b.String()
+}
+
+// Rule checks a path, returning any violations it finds.
+// Custom rules can be written as well as using those provided.
+type Rule func(path Path) []Violation
+
+// Validator checks paths against a list of rules.
+type Validator struct {
+	rules []Rule
+}
+
+// NewValidator returns a Validator that applies the rules.
+func NewValidator(rules ...Rule) *Validator {
+	return &Validator{rules: rules}
+}
+
+// Validate checks the path against all the rules. If there are any violations,
+// it returns a *ValidationError that lists them all; otherwise it returns nil.
+func (v *Validator) Validate(path Path) error {
+	return Validate(path, v.rules...)
+}
+
+// Validate checks the path against all the rules. If there are any violations,
+// it returns a *ValidationError that lists them all; otherwise it returns nil.
+func Validate(path Path, rules ...Rule) error {
+	var violations []Violation
+	for _, rule := range rules {
+		violations = append(violations, rule(path)...)
+	}
+	if len(violations) > 0 {
+		return &ValidationError{Path: path, Violations: violations}
+	}
+	return nil
+}
+
+// segmentRule builds a rule that checks each segment in turn; check returns
+// the reason for a violation, or "" if there is none.
+func segmentRule(check func(seg string) string) Rule {
+	return func(path Path) []Violation {
+		var violations []Violation
+		for i, seg := range path.All() {
+			if reason := check(seg); reason != "" {
+				violations = append(violations, Violation{Segment: i, Value: seg, Reason: reason})
+			}
+		}
+		return violations
+	}
+}
+
+// wholeRule builds a rule that checks the whole path; check returns the reason
+// for a violation, or "" if there is none.
+func wholeRule(check func(path Path) string) Rule {
+	return func(path Path) []Violation {
+		if reason := check(path); reason != "" {
+			return []Violation{{Segment: -1, Value: string(path), Reason: reason}}
+		}
+		return nil
+	}
+}
+
+// MaxLength is a rule that limits the total length of a path, in bytes.
+func MaxLength(n int) Rule {
+	return wholeRule(func(path Path) string {
+		if len(path) > n {
+			return fmt.Sprintf("is longer than %d bytes", n)
+		}
+		return ""
+	})
+}
+
+// MaxSegmentLength is a rule that limits the length of each segment, in bytes.
+func MaxSegmentLength(n int) Rule {
+	return segmentRule(func(seg string) string {
+		if len(seg) > n {
+			return fmt.Sprintf("is longer than %d bytes", n)
+		}
+		return ""
+	})
+}
+
+// MaxDepth is a rule that limits the number of segments in a path.
+func MaxDepth(n int) Rule {
+	return wholeRule(func(path Path) string {
+		depth := 0
+		for range path.All() {
+			depth++
+		}
+		if depth > n {
+			return fmt.Sprintf("has more than %d segments", n)
+		}
+		return ""
+	})
+}
+
+// AllowChars is a rule that allows only the characters accepted by the allowed
+// function in each segment. Invalid UTF-8 is never allowed. Only the first
+// disallowed character in each segment is reported.
+func AllowChars(allowed func(rune) bool) Rule {
+	return segmentRule(func(seg string) string {
+		if !utf8.ValidString(seg) {
+			return "contains invalid UTF-8"
+		}
+		for _, r := range seg {
+			if !allowed(r) {
+				return fmt.Sprintf("contains disallowed character %q", r)
+			}
+		}
+		return ""
+	})
+}
+
+// PortableChars reports whether a rune is in the POSIX portable filename
+// character set, i.e. an ASCII letter or digit, '.', '_' or '-'.
+// It can be used with AllowChars.
+func PortableChars(r rune) bool {
+	return r < utf8.RuneSelf && (isAlphaNum(byte(r)) || r == '.' || r == '_' || r == '-')
+}
+
+func isAlphaNum(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
+
+// ForbidNames is a rule that forbids segments with any of the given names.
+func ForbidNames(names ...string) Rule {
+	return segmentRule(func(seg string) string {
+		for _, name := range names {
+			if seg == name {
+				return "is a forbidden name"
+			}
+		}
+		return ""
+	})
+}
+
+// ForbidDotSegments is a rule that forbids "." and ".." segments.
+func ForbidDotSegments() Rule {
+	return segmentRule(func(seg string) string {
+		if seg == "." || seg == ".." {
+			return "is a dot segment"
+		}
+		return ""
+	})
+}
+
+// ForbidReservedNames is a rule that forbids the device names reserved by
+// Windows, such as CON, NUL and COM1, in any case and with or without an
+// extension.
+func ForbidReservedNames() Rule {
+	return segmentRule(func(seg string) string {
+		if isReservedName(seg) {
+			return "is a reserved name"
+		}
+		return ""
+	})
+}
+
+// ForbidEmptySegments is a rule that forbids empty segments, such as the one
+// in "a//b". A single leading or trailing slash does not count as an empty
+// segment.
+func ForbidEmptySegments() Rule {
+	return segmentRule(func(seg string) string {
+		if seg == "" {
+			return "is empty"
+		}
+		return ""
+	})
+}
+
+// RequireAbsolute is a rule that requires the path to be absolute.
+func RequireAbsolute() Rule {
+	return wholeRule(func(path Path) string {
+		if !path.IsAbs() {
+			return "is not absolute"
+		}
+		return ""
+	})
+}
+
+// RequireRelative is a rule that requires the path to be relative.
+func RequireRelative() Rule {
+	return wholeRule(func(path Path) string {
+		if path.IsAbs() {
+			return "is not relative"
+		}
+		return ""
+	})
+}
+
+// reservedNames are the device names reserved by Windows.
+var reservedNames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM0": true, "COM1": true, "COM2": true, "COM3": true, "COM4": true,
+	"COM5": true, "COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"COM¹": true, "COM²": true, "COM³": true,
+	"LPT0": true, "LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true,
+	"LPT5": true, "LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+	"LPT¹": true, "LPT²": true, "LPT³": true,
+}
+
+// isReservedName reports whether a name is reserved by Windows. Any extension
+// is ignored, as is trailing space before it, so "con.txt" and "NUL .x" are
+// both reserved.
+func isReservedName(name string) bool {
+	base, _, _ := strings.Cut(name, ".")
+	base = strings.TrimRight(base, " ")
+	return len(base) <= len("COM³") && reservedNames[strings.ToUpper(base)]
+}
@@ -0,0 +1,64 @@
+package path
+
+import (
+	"errors"
+	"testing"
+	"unicode"
+)
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		path       Path
+		rule       Rule
+		violations []Violation
+	}{
+		{"/a/b", MaxLength(4), nil},
+		{"/a/bc", MaxLength(4), []Violation{{-1, "/a/bc", "is longer than 4 bytes"}}},
+		{"/abc/de", MaxSegmentLength(2), []Violation{{0, "abc", "is longer than 2 bytes"}}},
+		{"/a/b/c", MaxDepth(3), nil},
+		{"/a/b/c/d", MaxDepth(3), []Violation{{-1, "/a/b/c/d", "has more than 3 segments"}}},
+		{"/a-b/c_d.e", AllowChars(PortableChars), nil},
+		{"/a b/c/d$e", AllowChars(PortableChars), []Violation{{0, "a b", "contains disallowed character ' '"}, {2, "d$e", "contains disallowed character '$'"}}},
+		{"/\xff", AllowChars(unicode.IsPrint), []Violation{{0, "\xff", "contains invalid UTF-8"}}},
+		{"/a/secret", ForbidNames("secret", ".git"), []Violation{{1, "secret", "is a forbidden name"}}},
+		{"/a/./b/..", ForbidDotSegments(), []Violation{{1, ".", "is a dot segment"}, {3, "..", "is a dot segment"}}},
+		{"/a/con.txt/Lpt1/COM10", ForbidReservedNames(), []Violation{{1, "con.txt", "is a reserved name"}, {2, "Lpt1", "is a reserved name"}}},
+		{"/a//b/", ForbidEmptySegments(), []Violation{{1, "", "is empty"}}},
+		{"a/b", RequireAbsolute(), []Violation{{-1, "a/b", "is not absolute"}}},
+		{"/a/b", RequireAbsolute(), nil},
+		{"/a/b", RequireRelative(), []Violation{{-1, "/a/b", "is not relative"}}},
+	}
+
+	for _, c := range cases {
+		err := Validate(c.path, c.rule)
+		if c.violations == nil {
+			isNil(t, err, c.path)
+			continue
+		}
+		var ve *ValidationError
+		isEqual(t, errors.As(err, &ve), true, c.path)
+		isEqual(t, ve.Path, c.path, c.path)
+		isEqual(t, ve.Violations, c.violations, c.path)
+	}
+}
+
+func TestValidatorListsEveryViolation(t *testing.T) {
+	v := NewValidator(RequireAbsolute(), MaxSegmentLength(8), ForbidDotSegments(), ForbidReservedNames())
+
+	isNil(t, v.Validate("/a/b/c.txt"), "")
+
+	err := v.Validate("a/../verylongname/aux")
+	isEqual(t, err.Error(), `invalid path "a/../verylongname/aux": is not absolute; `+
+		`segment 2 "verylongname" is longer than 8 bytes; segment 1 ".." is a dot segment; segment 3 "aux" is a reserved name`, "")
+}
+
+func TestValidateCustomRule(t *testing.T) {
+	mustBeClean := func(path Path) []Violation {
+		if path != path.Clean() {
+			return []Violation{{Segment: -1, Value: string(path), Reason: "is not clean"}}
+		}
+		return nil
+	}
+	isNil(t, Validate("/a/b", mustBeClean), "")
+	isEqual(t, Validate("/a//b", mustBeClean).Error(), `invalid path "/a//b": is not clean`, "")
+}